@@ -29,22 +29,18 @@ resource "active24_dns_record" "web" {
 }
 
 # --- Multiple A records on the same name (round-robin) ---
-resource "active24_dns_record" "app_1" {
+# A record set manages every value of one name and type together.
+resource "active24_dns_record_set" "app" {
   domain  = "example.com"
   service = "12345678"
   name    = "app"
   type    = "A"
-  content = "10.0.0.1"
   ttl     = 300
-}
 
-resource "active24_dns_record" "app_2" {
-  domain  = "example.com"
-  service = "12345678"
-  name    = "app"
-  type    = "A"
-  content = "10.0.0.2"
-  ttl     = 300
+  records = [
+    { content = "10.0.0.1" },
+    { content = "10.0.0.2" },
+  ]
 }
 
 # --- CNAME record ---
@@ -70,6 +66,22 @@ resource "active24_dns_record" "mail" {
   ttl      = 3600
 }
 
+# --- SRV record ---
+# Service location record: _sip._tcp.voip.example.com
+resource "active24_dns_record" "sip" {
+  domain       = "example.com"
+  service      = "12345678"
+  name         = "voip"
+  type         = "SRV"
+  srv_service  = "_sip"
+  srv_protocol = "_tcp"
+  priority     = 10
+  srv_weight   = 5
+  srv_port     = 5060
+  srv_target   = "sip.example.com"
+  ttl          = 3600
+}
+
 # --- TXT record ---
 # Commonly used for SPF, DKIM, domain verification, etc.
 resource "active24_dns_record" "spf" {
@@ -0,0 +1,603 @@
+// Package fakeapi implements an in-process fake of the Active24 REST v2 DNS
+// record and service list endpoints for tests. It verifies request signatures
+// the same way the real API does, so tests exercise the provider's client end
+// to end without network.
+package fakeapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is a DNS record as stored and returned by the fake API.
+type Record struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Content  string `json:"content"`
+	TTL      int64  `json:"ttl"`
+	Priority *int64 `json:"priority,omitempty"`
+	CAAValue string `json:"caaValue,omitempty"`
+	Flags    *int64 `json:"flags,omitempty"`
+	Tag      string `json:"tag,omitempty"`
+	Weight   *int64 `json:"weight,omitempty"`
+	Port     *int64 `json:"port,omitempty"`
+}
+
+// Service is an account service as returned by the service list.
+type Service struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	ServiceName    string `json:"serviceName"`
+	Status         string `json:"status"`
+	ExpirationDate string `json:"expirationDate,omitempty"`
+}
+
+// Failure describes an injected error response. It matches requests by
+// method (empty matches any) and path suffix (empty matches any) and is
+// served Count times before the request is handled normally. Delay holds the
+// response back; with Status 0 the request is then handled normally, which
+// emulates a slow API.
+type Failure struct {
+	Method     string
+	PathSuffix string
+	Status     int
+	Body       string
+	RetryAfter string
+	Delay      time.Duration
+	Count      int
+}
+
+// Server is a fake Active24 API. Create it with New and point the provider's
+// base_url at BaseURL().
+type Server struct {
+	*httptest.Server
+
+	APIKey    string
+	APISecret string
+
+	// PageSize is the default number of records per list page.
+	PageSize int
+	// FQDNNames makes list/get responses return record names as FQDNs using
+	// the domain registered with AddService.
+	FQDNNames bool
+	// RelativeNextPageURL makes record lists link the next page only by a
+	// nextPageUrl relative to the API root, e.g. service/1/dns/record?page=2,
+	// instead of currentPage/totalPages metadata.
+	RelativeNextPageURL bool
+	// OmitCreateBody makes POST return an empty body, forcing the client to
+	// find the created record by listing.
+	OmitCreateBody bool
+	// MaxClockSkew is the accepted difference between X-Date and server time.
+	MaxClockSkew time.Duration
+
+	mu       sync.Mutex
+	nextID   int64
+	records  map[string]map[int64]Record // service -> id -> record
+	domains  map[string]string           // service -> domain
+	services []Service
+	failures []*Failure
+	requests []string
+}
+
+// New starts a fake API accepting requests signed with apiKey/apiSecret.
+func New(apiKey, apiSecret string) *Server {
+	s := &Server{
+		APIKey:       apiKey,
+		APISecret:    apiSecret,
+		PageSize:     20,
+		MaxClockSkew: 5 * time.Minute,
+		nextID:       300000000,
+		records:      map[string]map[int64]Record{},
+		domains:      map[string]string{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/service", s.handleServices)
+	mux.HandleFunc("GET /v2/service/{service}/dns/record", s.handleList)
+	mux.HandleFunc("POST /v2/service/{service}/dns/record", s.handleCreate)
+	mux.HandleFunc("GET /v2/service/{service}/dns/record/{id}", s.handleGet)
+	mux.HandleFunc("PUT /v2/service/{service}/dns/record/{id}", s.handleUpdate)
+	mux.HandleFunc("DELETE /v2/service/{service}/dns/record/{id}", s.handleDelete)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// BaseURL returns the value to use as the provider's base_url.
+func (s *Server) BaseURL() string {
+	return s.URL + "/v2"
+}
+
+// AddService registers a domain service with a DNS zone. It is listed by
+// the service endpoint when service is numeric.
+func (s *Server) AddService(service, domain string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.domains[service] = domain
+	s.zone(service)
+	if id, err := strconv.ParseInt(service, 10, 64); err == nil {
+		s.services = append(s.services, Service{ID: id, Name: domain, ServiceName: "domain", Status: "active"})
+	}
+}
+
+// AddAccountService lists svc on the account without creating a DNS zone,
+// e.g. a hosting or mail service.
+func (s *Server) AddAccountService(svc Service) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.services = append(s.services, svc)
+}
+
+// Put stores a record directly, bypassing validation. A zero ID is assigned.
+func (s *Server) Put(service string, rec Record) Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rec.ID == 0 {
+		s.nextID++
+		rec.ID = s.nextID
+	}
+	s.zone(service)[rec.ID] = rec
+	return rec
+}
+
+// Records returns the stored records of service ordered by ID, with relative names.
+func (s *Server) Records(service string) []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sorted(service)
+}
+
+// Fail queues an injected failure.
+func (s *Server) Fail(f Failure) {
+	if f.Count == 0 {
+		f.Count = 1
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &f)
+}
+
+// Requests returns "METHOD path" for every request received so far.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.mu.Unlock()
+
+		if err := s.verifySignature(r); err != nil {
+			writeError(w, http.StatusUnauthorized, "Unauthorized", err.Error(), nil)
+			return
+		}
+		if f := s.takeFailure(r); f != nil {
+			if f.Delay > 0 {
+				t := time.NewTimer(f.Delay)
+				select {
+				case <-t.C:
+				case <-r.Context().Done():
+					// The client gave up, the request has no effect
+					t.Stop()
+					return
+				}
+			}
+			if f.Status == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if f.RetryAfter != "" {
+				w.Header().Set("Retry-After", f.RetryAfter)
+			}
+			body := f.Body
+			if body == "" {
+				body = fmt.Sprintf(`{"title":%q,"status":%d}`, http.StatusText(f.Status), f.Status)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(f.Status)
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// verifySignature checks the HMAC-SHA1 Basic auth scheme: the password is
+// hex(HMAC-SHA1(secret, "METHOD PATH UNIXTIME")) where the time comes from X-Date.
+func (s *Server) verifySignature(r *http.Request) error {
+	date, err := time.Parse("20060102T150405Z", r.Header.Get("X-Date"))
+	if err != nil {
+		return fmt.Errorf("invalid or missing X-Date header")
+	}
+	if skew := time.Since(date); skew > s.MaxClockSkew || skew < -s.MaxClockSkew {
+		return fmt.Errorf("X-Date outside allowed clock skew")
+	}
+	user, pass, ok := r.BasicAuth()
+	if !ok {
+		return fmt.Errorf("missing Basic authorization")
+	}
+	if user != s.APIKey {
+		return fmt.Errorf("unknown API key")
+	}
+	canonical := fmt.Sprintf("%s %s %d", r.Method, r.URL.Path, date.Unix())
+	mac := hmac.New(sha1.New, []byte(s.APISecret))
+	mac.Write([]byte(canonical))
+	if !hmac.Equal([]byte(pass), []byte(fmt.Sprintf("%x", mac.Sum(nil)))) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+func (s *Server) takeFailure(r *http.Request) *Failure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.failures {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if f.PathSuffix != "" && !strings.HasSuffix(r.URL.Path, f.PathSuffix) {
+			continue
+		}
+		f.Count--
+		if f.Count <= 0 {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	service := r.PathValue("service")
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	if _, ok := s.records[service]; !ok {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "Not Found", "service not found", nil)
+		return
+	}
+	var matched []Record
+	for _, rec := range s.sorted(service) {
+		out := s.present(service, rec)
+		// Active24 matches name and content filters by substring, names
+		// case-insensitively
+		if v := q.Get("filters[name]"); v != "" && !strings.Contains(strings.ToLower(out.Name), strings.ToLower(v)) {
+			continue
+		}
+		if v := q.Get("filters[type]"); v != "" && !strings.EqualFold(rec.Type, v) {
+			continue
+		}
+		if v := q.Get("filters[content]"); v != "" && !strings.Contains(rec.Content, v) {
+			continue
+		}
+		if v := q.Get("filters[ttl]"); v != "" && strconv.FormatInt(rec.TTL, 10) != v {
+			continue
+		}
+		matched = append(matched, out)
+	}
+	s.mu.Unlock()
+
+	start, end, meta := s.paginate(q, len(matched))
+	if s.RelativeNextPageURL {
+		page := meta["currentPage"].(int)
+		meta = map[string]any{}
+		if end < len(matched) {
+			q.Set("page", strconv.Itoa(page+1))
+			meta["nextPageUrl"] = "service/" + service + "/dns/record?" + q.Encode()
+		}
+	}
+	meta["data"] = append([]Record{}, matched[start:end]...)
+	writeJSON(w, http.StatusOK, meta)
+}
+
+func (s *Server) handleServices(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	services := append([]Service{}, s.services...)
+	s.mu.Unlock()
+	sort.Slice(services, func(i, j int) bool { return services[i].ID < services[j].ID })
+
+	start, end, meta := s.paginate(r.URL.Query(), len(services))
+	meta["data"] = services[start:end]
+	writeJSON(w, http.StatusOK, meta)
+}
+
+// paginate returns the bounds of the requested page of n items and the
+// paging metadata of the response.
+func (s *Server) paginate(q url.Values, n int) (int, int, map[string]any) {
+	size := s.PageSize
+	if v, err := strconv.Atoi(q.Get("rowsPerPage")); err == nil && v > 0 && v < size {
+		size = v
+	}
+	page := 1
+	if v, err := strconv.Atoi(q.Get("page")); err == nil && v > 0 {
+		page = v
+	}
+	totalPages := (n + size - 1) / size
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	start := (page - 1) * size
+	if start > n {
+		start = n
+	}
+	end := start + size
+	if end > n {
+		end = n
+	}
+	return start, end, map[string]any{
+		"currentPage":  page,
+		"totalPages":   totalPages,
+		"totalRecords": n,
+		"rowsPerPage":  size,
+	}
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	service := r.PathValue("service")
+	var in Record
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request", "invalid JSON body", nil)
+		return
+	}
+	if status, title, fields := validate(in); status != 0 {
+		writeError(w, status, title, "", fields)
+		return
+	}
+
+	s.mu.Lock()
+	if _, ok := s.records[service]; !ok {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "Not Found", "service not found", nil)
+		return
+	}
+	s.nextID++
+	in.ID = s.nextID
+	in.Name = s.relative(service, in.Name)
+	s.zone(service)[in.ID] = in
+	out := s.present(service, in)
+	s.mu.Unlock()
+
+	if s.OmitCreateBody {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusCreated, out)
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	service, id, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	out := s.present(service, s.records[service][id])
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	service, id, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	var in Record
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request", "invalid JSON body", nil)
+		return
+	}
+	if status, title, fields := validate(in); status != 0 {
+		writeError(w, status, title, "", fields)
+		return
+	}
+	s.mu.Lock()
+	in.ID = id
+	in.Name = s.relative(service, in.Name)
+	s.records[service][id] = in
+	out := s.present(service, in)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	service, id, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	delete(s.records[service], id)
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) lookup(w http.ResponseWriter, r *http.Request) (string, int64, bool) {
+	service := r.PathValue("service")
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Not Found", "record not found", nil)
+		return "", 0, false
+	}
+	s.mu.Lock()
+	_, ok := s.records[service][id]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found", "record not found", nil)
+		return "", 0, false
+	}
+	return service, id, true
+}
+
+// validate emulates the Active24 record validation quirks: content is required
+// for every type (including CAA), CAA needs caaValue/flags/tag, sending CAA
+// fields for other types fails with a 500, SRV needs priority/weight/port
+// with only the target hostname in content, and TXT strings are limited to
+// 255 bytes.
+func validate(in Record) (int, string, map[string]string) {
+	fields := map[string]string{}
+	if in.Type == "" {
+		fields["type"] = "This value should not be blank."
+	}
+	if in.Content == "" {
+		fields["content"] = "This value should not be blank."
+	}
+	if strings.EqualFold(in.Type, "CAA") {
+		if in.CAAValue == "" {
+			fields["caaValue"] = "This value should not be blank."
+		}
+		if in.Flags == nil {
+			fields["flags"] = "This value should not be null."
+		}
+		if in.Tag == "" {
+			fields["tag"] = "This value should not be blank."
+		}
+	} else if in.CAAValue != "" || in.Flags != nil || in.Tag != "" {
+		return http.StatusInternalServerError, "Internal Server Error", nil
+	}
+	if strings.EqualFold(in.Type, "TXT") && !validTXT(in.Content) {
+		fields["content"] = "Each TXT string must be at most 255 characters long."
+	}
+	if !isASCII(in.Name) {
+		fields["name"] = "This value is not a valid hostname."
+	}
+	switch strings.ToUpper(in.Type) {
+	case "CNAME", "MX", "NS", "PTR", "SRV":
+		if !isASCII(in.Content) {
+			fields["content"] = "This value is not a valid hostname."
+		}
+	}
+	if strings.EqualFold(in.Type, "SRV") {
+		if in.Priority == nil {
+			fields["priority"] = "This value should not be null."
+		}
+		if in.Weight == nil {
+			fields["weight"] = "This value should not be null."
+		}
+		if in.Port == nil {
+			fields["port"] = "This value should not be null."
+		}
+		if strings.ContainsAny(in.Content, " \t") {
+			fields["content"] = "This value is not a valid hostname."
+		}
+	}
+	if len(fields) > 0 {
+		return http.StatusBadRequest, "Validation failed", fields
+	}
+	return 0, "", nil
+}
+
+// isASCII reports whether s is free of non-ASCII characters. Like the real
+// API, the fake only accepts internationalized names as A-labels.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// validTXT reports whether every character-string of a TXT content fits into
+// 255 bytes. Content not written as quoted strings is one string.
+func validTXT(content string) bool {
+	if !strings.HasPrefix(content, `"`) {
+		return len(content) <= 255
+	}
+	for _, part := range strings.Split(content, `" "`) {
+		part = strings.Trim(part, `"`)
+		part = strings.ReplaceAll(part, `\"`, `"`)
+		if len(part) > 255 {
+			return false
+		}
+	}
+	return true
+}
+
+// relative strips the zone suffix from a name sent by the client.
+func (s *Server) relative(service, name string) string {
+	domain := s.domains[service]
+	if domain == "" {
+		return name
+	}
+	name = strings.TrimSuffix(name, ".")
+	if name == domain {
+		return ""
+	}
+	return strings.TrimSuffix(name, "."+domain)
+}
+
+// present converts a stored record to its API representation.
+func (s *Server) present(service string, rec Record) Record {
+	domain := s.domains[service]
+	if s.FQDNNames && domain != "" {
+		if rec.Name == "" {
+			rec.Name = domain
+		} else {
+			rec.Name = rec.Name + "." + domain
+		}
+	}
+	return rec
+}
+
+func (s *Server) zone(service string) map[int64]Record {
+	z, ok := s.records[service]
+	if !ok {
+		z = map[int64]Record{}
+		s.records[service] = z
+	}
+	return z
+}
+
+func (s *Server) sorted(service string) []Record {
+	zone := s.records[service]
+	out := make([]Record, 0, len(zone))
+	for _, rec := range zone {
+		out = append(out, rec)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	writeJSONBody(w, status, v)
+}
+
+func writeError(w http.ResponseWriter, status int, title, detail string, fields map[string]string) {
+	body := map[string]any{"title": title, "status": status}
+	if detail != "" {
+		body["detail"] = detail
+	}
+	if len(fields) > 0 {
+		var params []map[string]string
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			params = append(params, map[string]string{"name": name, "reason": fields[name]})
+		}
+		body["invalidParameters"] = params
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	writeJSONBody(w, status, body)
+}
+
+func writeJSONBody(w http.ResponseWriter, status int, v any) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,19 @@
+package provider
+
+import "context"
+
+// DNSAPI is the set of DNS record and service operations used by resources
+// and data sources. *Client implements it against the Active24 REST API; FakeDNS is an
+// in-memory implementation for offline tests.
+type DNSAPI interface {
+	CreateRecord(ctx context.Context, service string, req RecordRequest) (*DNSRecord, error)
+	GetRecord(ctx context.Context, service string, id int64) (*DNSRecord, error)
+	UpdateRecord(ctx context.Context, service string, id int64, req RecordRequest) (*DNSRecord, error)
+	DeleteRecord(ctx context.Context, service string, id int64) error
+	ListRecords(ctx context.Context, service string, name string, rtype string, content string, ttl *int64) ([]DNSRecord, error)
+
+	ListServices(ctx context.Context) ([]Service, error)
+	ResolveService(ctx context.Context, domain string) (string, error)
+}
+
+var _ DNSAPI = (*Client)(nil)
@@ -7,16 +7,22 @@ import (
 	"crypto/sha1"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
+	"net"
 	"net/http"
 	neturl "net/url"
 	"os"
 	"path"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
 )
 
 // Client is a small HTTP client for Active24 API
@@ -25,17 +31,94 @@ type Client struct {
 	httpClient *http.Client
 	apiKey     string
 	apiSecret  string
+
+	// Retry policy for transient failures (429, 5xx, connection errors)
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+
+	// limiter throttles requests across all resources sharing this client
+	limiter *rateLimiter
+
+	// services caches the account's service list for ResolveService
+	servicesMu   sync.Mutex
+	services     []Service
+	servicesLoad singleflight.Group
+}
+
+// ClientOption customizes a Client created by NewClient.
+type ClientOption func(*Client)
+
+// WithRetry sets how many times a failed request is retried and the bounds
+// of the exponential backoff between attempts.
+func WithRetry(maxRetries int, waitMin, waitMax time.Duration) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryWaitMin = waitMin
+		c.retryWaitMax = waitMax
+	}
+}
+
+// WithRequestTimeout sets the time limit of a single HTTP request, including
+// reading the response. Zero disables the limit; the operation's context
+// deadline still applies.
+func WithRequestTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Timeout = d
+	}
 }
 
-func NewClient(baseURL string, apiKey string, apiSecret string) (*Client, error) {
+// WithRateLimit sets the client-side request rate. A non-positive rps
+// disables the configured limit; rate-limit headers from the API are still honoured.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(rps, burst)
+	}
+}
+
+const (
+	// defaultBaseURL is the Active24 REST v2 API base per docs
+	defaultBaseURL = "https://rest.active24.cz/v2"
+
+	defaultRequestTimeout = 15 * time.Second
+
+	defaultRequestsPerSecond = 5
+	defaultBurst             = 10
+
+	defaultMaxRetries   = 3
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+func NewClient(baseURL string, apiKey string, apiSecret string, opts ...ClientOption) (*Client, error) {
 	parsed, err := neturl.Parse(baseURL)
 	if err != nil {
 		return nil, err
 	}
 
-	hc := &http.Client{Timeout: 15 * time.Second}
+	hc := &http.Client{Timeout: defaultRequestTimeout}
 
-	return &Client{baseURL: parsed, httpClient: hc, apiKey: apiKey, apiSecret: apiSecret}, nil
+	c := &Client{
+		baseURL:      parsed,
+		httpClient:   hc,
+		apiKey:       apiKey,
+		apiSecret:    apiSecret,
+		maxRetries:   defaultMaxRetries,
+		retryWaitMin: defaultRetryWaitMin,
+		retryWaitMax: defaultRetryWaitMax,
+		limiter:      newRateLimiter(defaultRequestsPerSecond, defaultBurst),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.maxRetries < 0 {
+		c.maxRetries = 0
+	}
+	if c.retryWaitMax < c.retryWaitMin {
+		c.retryWaitMax = c.retryWaitMin
+	}
+	c.limiter.maxPause = c.retryWaitMax
+	return c, nil
 }
 
 func (c *Client) buildURL(elem ...string) string {
@@ -45,106 +128,244 @@ func (c *Client) buildURL(elem ...string) string {
 	return u.String()
 }
 
-func (c *Client) do(ctx context.Context, method string, requestURL string, in any, out any) error {
-	var body io.Reader
-	var payload []byte
-	if in != nil {
-		b, err := json.Marshal(in)
-		if err != nil {
-			return err
-		}
-		payload = b
-		body = bytes.NewReader(b)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
-	if err != nil {
-		return err
-	}
+// sign sets the Active24 v2 HMAC Basic headers on req. It must be called for
+// every attempt because the signature embeds the current timestamp.
+func (c *Client) sign(req *http.Request) {
 	// Active24 v2 HMAC Basic: password is signature of canonical request
 	now := time.Now().UTC()
 	unixTs := strconv.FormatInt(now.Unix(), 10)
-	parsedURL, _ := neturl.Parse(requestURL)
 	// Sign ONLY the path per observed behavior (omit query from canonical)
-	canonical := fmt.Sprintf("%s %s %s", method, parsedURL.Path, unixTs)
+	canonical := fmt.Sprintf("%s %s %s", req.Method, req.URL.Path, unixTs)
 	mac := hmac.New(sha1.New, []byte(c.apiSecret))
 	mac.Write([]byte(canonical))
 	signature := fmt.Sprintf("%x", mac.Sum(nil))
 	auth := base64.StdEncoding.EncodeToString([]byte(c.apiKey + ":" + signature))
 
 	req.Header.Set("Authorization", "Basic "+auth)
-	req.Header.Set("User-Agent", "terraform-provider-active24")
-	req.Header.Set("Accept", "application/json")
 	// X-Date must match the timestamp used in the canonical string
 	req.Header.Set("X-Date", now.Format("20060102T150405Z"))
+}
+
+func (c *Client) do(ctx context.Context, method string, requestURL string, in any, out any) error {
+	var payload []byte
 	if in != nil {
-		req.Header.Set("Content-Type", "application/json")
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		payload = b
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+	for attempt := 0; ; attempt++ {
+		var body io.Reader
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+		if err != nil {
+			return err
+		}
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+		c.sign(req)
+		req.Header.Set("User-Agent", "terraform-provider-active24")
+		req.Header.Set("Accept", "application/json")
+		if in != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if isDebugEnabled() {
+				fmt.Printf("[active24] request error %s %s: %v\n", method, requestURL, err)
+			}
+			if attempt < c.maxRetries && ctx.Err() == nil && retryableError(method, err) {
+				if werr := c.wait(ctx, method, requestURL, attempt, 0); werr != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+
+		respBytes, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		c.limiter.Observe(resp)
 		if isDebugEnabled() {
-			fmt.Printf("[active24] request error %s %s: %v\n", method, requestURL, err)
+			fmt.Printf("[active24] %s %s -> %s\n", method, requestURL, resp.Status)
+			if len(payload) > 0 {
+				fmt.Printf("[active24] request body: %s\n", string(payload))
+			}
+			fmt.Printf("[active24] response body: %s\n", string(respBytes))
+			// Also log via Terraform logger so it shows with TF_LOG
+			tflog.Info(ctx, "active24 http", map[string]any{
+				"method":        method,
+				"url":           requestURL,
+				"status":        resp.Status,
+				"request_body":  string(payload),
+				"response_body": string(respBytes),
+			})
 		}
-		return err
-	}
-	defer resp.Body.Close()
 
-	respBytes, _ := io.ReadAll(resp.Body)
-	if isDebugEnabled() {
-		fmt.Printf("[active24] %s %s -> %s\n", method, requestURL, resp.Status)
-		if len(payload) > 0 {
-			fmt.Printf("[active24] request body: %s\n", string(payload))
+		if attempt < c.maxRetries && retryableStatus(method, resp.StatusCode) {
+			// A server asking for a longer pause than retry_wait_max fails the
+			// request with its error instead of stalling the apply
+			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			if retryAfter <= c.retryWaitMax {
+				if werr := c.wait(ctx, method, requestURL, attempt, retryAfter); werr == nil {
+					continue
+				}
+			}
 		}
-		fmt.Printf("[active24] response body: %s\n", string(respBytes))
-		// Also log via Terraform logger so it shows with TF_LOG
-		tflog.Info(ctx, "active24 http", map[string]any{
-			"method":        method,
-			"url":           requestURL,
-			"status":        resp.Status,
-			"request_body":  string(payload),
-			"response_body": string(respBytes),
-		})
+
+		if resp.StatusCode >= 300 {
+			return newAPIError(method, req, resp, respBytes)
+		}
+
+		if out != nil && len(respBytes) > 0 {
+			decoder := json.NewDecoder(bytes.NewReader(respBytes))
+			return decoder.Decode(out)
+		}
+
+		return nil
 	}
+}
 
-	if resp.StatusCode >= 300 {
-		return fmt.Errorf("active24 API error: %s: %s", resp.Status, string(respBytes))
+// wait sleeps before the next attempt. A positive retryAfter (from the
+// Retry-After header) takes precedence over the computed backoff.
+func (c *Client) wait(ctx context.Context, method, requestURL string, attempt int, retryAfter time.Duration) error {
+	d := retryAfter
+	if d <= 0 {
+		d = backoff(c.retryWaitMin, c.retryWaitMax, attempt)
+	}
+	tflog.Debug(ctx, "active24 retrying request", map[string]any{
+		"method":  method,
+		"url":     requestURL,
+		"attempt": attempt + 1,
+		"wait":    d.String(),
+	})
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
 	}
 
-	if out != nil && len(respBytes) > 0 {
-		decoder := json.NewDecoder(bytes.NewReader(respBytes))
-		return decoder.Decode(out)
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
 	}
+}
 
-	return nil
+// backoff returns an exponential delay for the given attempt with equal
+// jitter: half of the delay is fixed, the other half is random.
+func backoff(waitMin, waitMax time.Duration, attempt int) time.Duration {
+	d := waitMin
+	for i := 0; i < attempt && d < waitMax; i++ {
+		d *= 2
+	}
+	if d > waitMax {
+		d = waitMax
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int64N(int64(d-half)+1))
 }
 
-// DNS record models (based on common DNS fields; may need adjustments per API)
+// retryableStatus reports whether a response status is worth retrying.
+// 429 means the request was rejected before processing, so it is safe for
+// any method. 5xx responses are only retried for idempotent methods because
+// a POST may already have created the record.
+func retryableStatus(method string, status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	switch status {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+// retryableError reports whether a transport error is worth retrying. A POST
+// is only retried when the connection could not be established at all. An
+// attempt that ran into request_timeout is retried like any other transport
+// error; the caller stops once the operation's own deadline has passed.
+func retryableError(method string, err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if isIdempotent(method) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date. It returns 0 when the header is missing or invalid.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// DNS record models (based on common DNS fields; may need adjustments per API).
+// For SRV records Weight and Port are separate fields and Content is the target.
 type DNSRecord struct {
-	ID       int64   `json:"id"`
-	Name     string  `json:"name"`
-	Type     string  `json:"type"`
-	Content  string  `json:"content"`
-	TTL      int64   `json:"ttl"`
-	Priority *int64  `json:"priority,omitempty"`
-	CAAValue string  `json:"caaValue,omitempty"`
-	Flags    *int64  `json:"flags,omitempty"`
-	Tag      string  `json:"tag,omitempty"`
-}
-
-type createRecordRequest struct {
-	Name     string  `json:"name"`
-	Type     string  `json:"type"`
-	Content  string  `json:"content"`
-	TTL      int64   `json:"ttl"`
-	Priority *int64  `json:"priority,omitempty"`
-	CAAValue string  `json:"caaValue,omitempty"`
-	Flags    *int64  `json:"flags,omitempty"`
-	Tag      string  `json:"tag,omitempty"`
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Content  string `json:"content"`
+	TTL      int64  `json:"ttl"`
+	Priority *int64 `json:"priority,omitempty"`
+	CAAValue string `json:"caaValue,omitempty"`
+	Flags    *int64 `json:"flags,omitempty"`
+	Tag      string `json:"tag,omitempty"`
+	Weight   *int64 `json:"weight,omitempty"`
+	Port     *int64 `json:"port,omitempty"`
+}
+
+// RecordRequest is the payload for creating or updating a DNS record
+type RecordRequest struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Content  string `json:"content"`
+	TTL      int64  `json:"ttl"`
+	Priority *int64 `json:"priority,omitempty"`
+	CAAValue string `json:"caaValue,omitempty"`
+	Flags    *int64 `json:"flags,omitempty"`
+	Tag      string `json:"tag,omitempty"`
+	Weight   *int64 `json:"weight,omitempty"`
+	Port     *int64 `json:"port,omitempty"`
 }
 
 // CreateRecord creates a DNS record under a domain
-func (c *Client) CreateRecord(ctx context.Context, domain string, req createRecordRequest) (*DNSRecord, error) {
+func (c *Client) CreateRecord(ctx context.Context, domain string, req RecordRequest) (*DNSRecord, error) {
 	var out DNSRecord
 	// v2 API path: /v2/service/{service}/dns/record
 	url := c.buildURL("service", domain, "dns", "record")
@@ -163,7 +384,7 @@ func (c *Client) GetRecord(ctx context.Context, domain string, id int64) (*DNSRe
 	return &out, nil
 }
 
-func (c *Client) UpdateRecord(ctx context.Context, domain string, id int64, req createRecordRequest) (*DNSRecord, error) {
+func (c *Client) UpdateRecord(ctx context.Context, domain string, id int64, req RecordRequest) (*DNSRecord, error) {
 	var out DNSRecord
 	url := c.buildURL("service", domain, "dns", "record", fmt.Sprintf("%d", id))
 	if err := c.do(ctx, http.MethodPut, url, req, &out); err != nil {
@@ -177,13 +398,46 @@ func (c *Client) DeleteRecord(ctx context.Context, domain string, id int64) erro
 	return c.do(ctx, http.MethodDelete, url, nil, nil)
 }
 
-// dnsRecordsPage is a minimal response model for paginated list
+// dnsRecordsPage is the response model for the paginated record list.
+// Active24 reports paging either via currentPage/totalPages or nextPageUrl.
 type dnsRecordsPage struct {
-	Data []DNSRecord `json:"data"`
+	Data         []DNSRecord `json:"data"`
+	CurrentPage  int         `json:"currentPage"`
+	TotalPages   int         `json:"totalPages"`
+	TotalRecords int         `json:"totalRecords"`
+	RowsPerPage  int         `json:"rowsPerPage"`
+	NextPageURL  string      `json:"nextPageUrl"`
 }
 
-// ListRecords lists DNS records with basic filters (name/type/content/ttl)
-func (c *Client) ListRecords(ctx context.Context, domain string, name string, rtype string, content string, ttl *int64) ([]DNSRecord, error) {
+const (
+	// listPageSize is the number of records requested per page
+	listPageSize = 100
+	// maxListPages guards against endless paging on inconsistent metadata
+	maxListPages = 1000
+)
+
+// RecordIterator streams DNS records page by page. Use it like bufio.Scanner:
+//
+//	it := c.ListRecordsIter(ctx, service, "", "", "", nil)
+//	for it.Next() {
+//		rec := it.Record()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type RecordIterator struct {
+	ctx     context.Context
+	client  *Client
+	nextURL string
+	page    int
+	fetched int
+
+	buf []DNSRecord
+	cur DNSRecord
+	err error
+}
+
+// ListRecordsIter returns an iterator over all DNS records matching the
+// filters, fetching further pages only as they are consumed.
+func (c *Client) ListRecordsIter(ctx context.Context, domain string, name string, rtype string, content string, ttl *int64) *RecordIterator {
 	base := c.buildURL("service", domain, "dns", "record")
 	q := neturl.Values{}
 	if name != "" {
@@ -198,15 +452,139 @@ func (c *Client) ListRecords(ctx context.Context, domain string, name string, rt
 	if ttl != nil {
 		q.Set("filters[ttl]", fmt.Sprintf("%d", *ttl))
 	}
-	if enc := q.Encode(); enc != "" {
-		base = base + "?" + enc
+	q.Set("page", "1")
+	q.Set("rowsPerPage", strconv.Itoa(listPageSize))
+	return &RecordIterator{ctx: ctx, client: c, nextURL: base + "?" + q.Encode()}
+}
+
+// Next advances to the next record, fetching the next page when needed.
+func (it *RecordIterator) Next() bool {
+	for len(it.buf) == 0 {
+		if it.err != nil || it.nextURL == "" {
+			return false
+		}
+		if it.fetched >= maxListPages {
+			it.err = fmt.Errorf("active24: record list exceeded %d pages", maxListPages)
+			return false
+		}
+		it.fetchPage()
 	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Record returns the current record.
+func (it *RecordIterator) Record() DNSRecord {
+	return it.cur
+}
+
+// Err returns the first error encountered while paging.
+func (it *RecordIterator) Err() error {
+	return it.err
+}
+
+func (it *RecordIterator) fetchPage() {
+	requestURL := it.nextURL
+	it.nextURL = ""
 
 	var page dnsRecordsPage
-	if err := c.do(ctx, http.MethodGet, base, nil, &page); err != nil {
+	if err := it.client.do(it.ctx, http.MethodGet, requestURL, nil, &page); err != nil {
+		it.err = err
+		return
+	}
+	it.fetched++
+	it.page++
+	it.buf = page.Data
+	if len(page.Data) == 0 {
+		return
+	}
+
+	switch {
+	case page.NextPageURL != "":
+		next, err := it.client.resolveURL(requestURL, page.NextPageURL)
+		if err != nil {
+			it.err = fmt.Errorf("active24: invalid nextPageUrl %q: %w", page.NextPageURL, err)
+			return
+		}
+		if next != requestURL {
+			it.nextURL = next
+		}
+	case page.TotalPages > 0:
+		current := page.CurrentPage
+		if current == 0 {
+			current = it.page
+		}
+		if current < page.TotalPages {
+			it.nextURL = withPage(requestURL, current+1)
+		}
+	case page.TotalRecords > 0:
+		if it.fetched*pageSizeOf(page) < page.TotalRecords {
+			it.nextURL = withPage(requestURL, it.page+1)
+		}
+	}
+}
+
+func pageSizeOf(page dnsRecordsPage) int {
+	if page.RowsPerPage > 0 {
+		return page.RowsPerPage
+	}
+	return len(page.Data)
+}
+
+// withPage returns requestURL with its page query parameter set to page.
+func withPage(requestURL string, page int) string {
+	u, err := neturl.Parse(requestURL)
+	if err != nil {
+		return ""
+	}
+	q := u.Query()
+	q.Set("page", strconv.Itoa(page))
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// resolveURL resolves a possibly relative link returned by the API. A
+// query-only link refers to the request it was returned for, other relative
+// links are relative to the API root (the base URL, e.g. /v2/). Links to
+// another scheme or host are rejected, since requests carry the signed
+// credentials.
+func (c *Client) resolveURL(requestURL, ref string) (string, error) {
+	r, err := neturl.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	base := *c.baseURL
+	if r.Scheme == "" && r.Host == "" && r.Path == "" {
+		cur, err := neturl.Parse(requestURL)
+		if err != nil {
+			return "", err
+		}
+		base = *cur
+	} else if !strings.HasSuffix(base.Path, "/") {
+		// Without the trailing slash the last segment of the base path ("v2")
+		// would be replaced instead of resolved against
+		base.Path += "/"
+		base.RawPath = ""
+	}
+	resolved := base.ResolveReference(r)
+	if !strings.EqualFold(resolved.Scheme, c.baseURL.Scheme) || !strings.EqualFold(resolved.Host, c.baseURL.Host) {
+		return "", fmt.Errorf("link leaves the API origin %s://%s", c.baseURL.Scheme, c.baseURL.Host)
+	}
+	return resolved.String(), nil
+}
+
+// ListRecords lists DNS records with basic filters (name/type/content/ttl),
+// following pagination until all records are collected.
+func (c *Client) ListRecords(ctx context.Context, domain string, name string, rtype string, content string, ttl *int64) ([]DNSRecord, error) {
+	var records []DNSRecord
+	it := c.ListRecordsIter(ctx, domain, name, rtype, content, ttl)
+	for it.Next() {
+		records = append(records, it.Record())
+	}
+	if err := it.Err(); err != nil {
 		return nil, err
 	}
-	return page.Data, nil
+	return records, nil
 }
 
 func getEnv(key string) string {
@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JoystiC/terraform-provider-active24/internal/fakeapi"
+)
+
+func newTestClient(t *testing.T, srv *fakeapi.Server, opts ...ClientOption) *Client {
+	t.Helper()
+	opts = append([]ClientOption{
+		WithRetry(3, time.Millisecond, 5*time.Millisecond),
+		WithRateLimit(0, 1),
+	}, opts...)
+	c, err := NewClient(srv.BaseURL(), srv.APIKey, srv.APISecret, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestClientSignature(t *testing.T) {
+	srv := fakeapi.New("key", "secret")
+	defer srv.Close()
+	srv.AddService("123", "example.com")
+
+	c, err := NewClient(srv.BaseURL(), "key", "wrong-secret", WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.ListRecords(context.Background(), "123", "", "", "", nil)
+	if !IsUnauthorized(err) {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+
+	if _, err := newTestClient(t, srv).ListRecords(context.Background(), "123", "", "", "", nil); err != nil {
+		t.Fatalf("signed request rejected: %v", err)
+	}
+}
+
+func TestClientListRecordsPagination(t *testing.T) {
+	srv := fakeapi.New("key", "secret")
+	defer srv.Close()
+	srv.AddService("123", "example.com")
+	srv.PageSize = 3
+	for i := 0; i < 10; i++ {
+		srv.Put("123", fakeapi.Record{Name: fmt.Sprintf("host%d", i), Type: "A", Content: fmt.Sprintf("10.0.0.%d", i), TTL: 300})
+	}
+
+	c := newTestClient(t, srv)
+	records, err := c.ListRecords(context.Background(), "123", "", "", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 10 {
+		t.Fatalf("expected 10 records across pages, got %d", len(records))
+	}
+
+	it := c.ListRecordsIter(context.Background(), "123", "host1", "A", "", nil)
+	var names []string
+	for it.Next() {
+		names = append(names, it.Record().Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != "host1" {
+		t.Fatalf("unexpected filtered records: %v", names)
+	}
+}
+
+func TestClientListRecordsRelativeNextPage(t *testing.T) {
+	srv := fakeapi.New("key", "secret")
+	defer srv.Close()
+	srv.AddService("123", "example.com")
+	srv.PageSize = 2
+	srv.RelativeNextPageURL = true
+	for i := 0; i < 5; i++ {
+		srv.Put("123", fakeapi.Record{Name: fmt.Sprintf("host%d", i), Type: "A", Content: fmt.Sprintf("10.0.0.%d", i), TTL: 300})
+	}
+
+	c := newTestClient(t, srv)
+	records, err := c.ListRecords(context.Background(), "123", "", "", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 5 {
+		t.Fatalf("expected 5 records across pages, got %d", len(records))
+	}
+}
+
+func TestClientResolveURL(t *testing.T) {
+	c, err := NewClient("https://rest.example.com/v2", "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := "https://rest.example.com/v2/service/1/dns/record?page=1"
+	for ref, want := range map[string]string{
+		"service/1/dns/record?page=2":                      "https://rest.example.com/v2/service/1/dns/record?page=2",
+		"/v2/service/1/dns/record?page=2":                  "https://rest.example.com/v2/service/1/dns/record?page=2",
+		"?page=2":                                          "https://rest.example.com/v2/service/1/dns/record?page=2",
+		"https://rest.example.com/v2/service/1/dns/record": "https://rest.example.com/v2/service/1/dns/record",
+	} {
+		got, err := c.resolveURL(current, ref)
+		if err != nil || got != want {
+			t.Errorf("resolveURL(%q) = %q, %v; want %q", ref, got, err, want)
+		}
+	}
+
+	// Signed requests must not follow links to another origin
+	for _, ref := range []string{
+		"https://evil.example.net/v2/service/1/dns/record?page=2",
+		"//evil.example.net/v2/service/1/dns/record?page=2",
+		"http://rest.example.com/v2/service/1/dns/record?page=2",
+	} {
+		if got, err := c.resolveURL(current, ref); err == nil {
+			t.Errorf("resolveURL(%q) = %q, want an error", ref, got)
+		}
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	srv := fakeapi.New("key", "secret")
+	defer srv.Close()
+	srv.AddService("123", "example.com")
+	c := newTestClient(t, srv)
+	ctx := context.Background()
+
+	// Idempotent requests are retried on 5xx
+	srv.Fail(fakeapi.Failure{Method: http.MethodGet, Status: http.StatusServiceUnavailable, Count: 2})
+	if _, err := c.ListRecords(ctx, "123", "", "", "", nil); err != nil {
+		t.Fatalf("expected GET to succeed after retries: %v", err)
+	}
+
+	// POST is retried on 429 because nothing was created
+	srv.Fail(fakeapi.Failure{Method: http.MethodPost, Status: http.StatusTooManyRequests, RetryAfter: "0"})
+	rec, err := c.CreateRecord(ctx, "123", RecordRequest{Name: "www", Type: "A", Content: "10.0.0.1", TTL: 300})
+	if err != nil {
+		t.Fatalf("expected POST to succeed after 429: %v", err)
+	}
+
+	// A Retry-After beyond the maximum backoff fails at once
+	srv.Fail(fakeapi.Failure{Method: http.MethodGet, Status: http.StatusTooManyRequests, RetryAfter: "86400"})
+	start := time.Now()
+	if _, err := c.GetRecord(ctx, "123", rec.ID); statusOf(err) != http.StatusTooManyRequests {
+		t.Fatalf("expected GET to fail with 429, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("GET with a long Retry-After returned after %s", elapsed)
+	}
+	// and does not hold back the next request
+	if _, err := c.GetRecord(ctx, "123", rec.ID); err != nil {
+		t.Fatalf("expected the next GET to succeed: %v", err)
+	}
+
+	// POST is not retried on 5xx, the record might have been created
+	srv.Fail(fakeapi.Failure{Method: http.MethodPost, Status: http.StatusBadGateway})
+	if _, err := c.CreateRecord(ctx, "123", RecordRequest{Name: "www", Type: "A", Content: "10.0.0.2", TTL: 300}); statusOf(err) != http.StatusBadGateway {
+		t.Fatalf("expected POST to fail with 502, got %v", err)
+	}
+
+	// Retries give up after max_retries
+	srv.Fail(fakeapi.Failure{Method: http.MethodDelete, Status: http.StatusServiceUnavailable, Count: 10})
+	if err := c.DeleteRecord(ctx, "123", rec.ID); statusOf(err) != http.StatusServiceUnavailable {
+		t.Fatalf("expected DELETE to fail with 503, got %v", err)
+	}
+}
+
+func TestClientTimeouts(t *testing.T) {
+	srv := fakeapi.New("key", "secret")
+	defer srv.Close()
+	srv.AddService("123", "example.com")
+	c := newTestClient(t, srv, WithRequestTimeout(50*time.Millisecond))
+
+	// A slow response exceeds the request timeout and is retried
+	srv.Fail(fakeapi.Failure{Method: http.MethodGet, Delay: time.Second})
+	if _, err := c.ListRecords(context.Background(), "123", "", "", "", nil); err != nil {
+		t.Fatalf("expected GET to succeed after a timed out attempt: %v", err)
+	}
+
+	// The operation deadline stops retries
+	c = newTestClient(t, srv, WithRequestTimeout(0))
+	srv.Fail(fakeapi.Failure{Method: http.MethodGet, Delay: time.Second, Count: 10})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.ListRecords(ctx, "123", "", "", "", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("request returned after %s, expected it to stop at the deadline", d)
+	}
+}
+
+func TestClientAPIError(t *testing.T) {
+	srv := fakeapi.New("key", "secret")
+	defer srv.Close()
+	srv.AddService("123", "example.com")
+	c := newTestClient(t, srv)
+	ctx := context.Background()
+
+	_, err := c.CreateRecord(ctx, "123", RecordRequest{Name: "@", Type: "CAA", TTL: 300})
+	if !IsValidation(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	apiErr := err.(*APIError)
+	for _, field := range []string{"content", "caaValue", "flags", "tag"} {
+		if len(apiErr.FieldErrors[field]) == 0 {
+			t.Errorf("expected field error for %s, got %v", field, apiErr.FieldErrors)
+		}
+	}
+
+	if _, err := c.GetRecord(ctx, "123", 42); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestClientResolveService(t *testing.T) {
+	srv := fakeapi.New("key", "secret")
+	defer srv.Close()
+	srv.PageSize = 2
+	srv.AddAccountService(fakeapi.Service{ID: 100, Name: "example.com", ServiceName: "hosting", Status: "active"})
+	srv.AddService("200", "example.com")
+	srv.AddAccountService(fakeapi.Service{ID: 300, Name: "example.net", ServiceName: "domain", Status: "active"})
+	srv.AddAccountService(fakeapi.Service{ID: 400, Name: "example.net", ServiceName: "dns", Status: "active"})
+	srv.AddService("500", "xn--pklad-zsa96e.cz")
+	c := newTestClient(t, srv)
+	ctx := context.Background()
+
+	for domain, want := range map[string]string{
+		"example.com":  "200",
+		"Example.NET.": "400",
+		"příklad.cz":   "500",
+	} {
+		got, err := c.ResolveService(ctx, domain)
+		if err != nil || got != want {
+			t.Errorf("ResolveService(%q) = %q, %v, want %q", domain, got, err, want)
+		}
+	}
+	if _, err := c.ResolveService(ctx, "example.org"); err == nil {
+		t.Error("expected an error for a domain without service")
+	}
+
+	// The list spans three pages and is fetched only once
+	var listed int
+	for _, r := range srv.Requests() {
+		if r == "GET /v2/service" {
+			listed++
+		}
+	}
+	if listed != 3 {
+		t.Errorf("expected the service list to be fetched once (3 pages), got %d requests", listed)
+	}
+}
+
+func TestClientResolveServiceConcurrent(t *testing.T) {
+	srv := fakeapi.New("key", "secret")
+	defer srv.Close()
+	srv.AddService("200", "example.com")
+	srv.Fail(fakeapi.Failure{Method: http.MethodGet, PathSuffix: "/service", Delay: 50 * time.Millisecond})
+	c := newTestClient(t, srv)
+
+	// Lookups waiting for the slow list share it instead of listing again
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got, err := c.ResolveService(context.Background(), "example.com"); err != nil || got != "200" {
+				errs <- fmt.Errorf("ResolveService = %q, %v", got, err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	var listed int
+	for _, r := range srv.Requests() {
+		if r == "GET /v2/service" {
+			listed++
+		}
+	}
+	if listed != 1 {
+		t.Errorf("expected one service list request, got %d", listed)
+	}
+
+	// A caller whose context ends does not wait for someone else's fetch
+	c = newTestClient(t, srv)
+	srv.Fail(fakeapi.Failure{Method: http.MethodGet, PathSuffix: "/service", Delay: 300 * time.Millisecond})
+	go c.ResolveService(context.Background(), "example.com")
+	time.Sleep(10 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.ResolveService(ctx, "example.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ResolveService with an expired context = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("ResolveService returned after %s", elapsed)
+	}
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The API may return record content in a different but equivalent spelling
+// than it was sent in: with a trailing dot, in another case, with IPv6
+// compressed differently or with TXT values quoted. Comparing contents with
+// contentEqual lets Read keep the configured spelling instead of reporting a
+// diff that can never be applied away.
+
+// normalizeContent returns the canonical form of content for rtype.
+func normalizeContent(rtype, content string) string {
+	content = strings.TrimSpace(content)
+	switch strings.ToUpper(rtype) {
+	case "A", "AAAA":
+		if addr, err := netip.ParseAddr(content); err == nil {
+			return addr.String()
+		}
+	case "CNAME", "MX", "NS", "PTR":
+		return normalizeHostname(content)
+	case "SRV":
+		if srv, err := parseSRVContent(content); err == nil {
+			return formatSRVContent(srv.Weight, srv.Port, normalizeHostname(srv.Target))
+		}
+		return normalizeHostname(content)
+	case "TXT":
+		return txtValue(content)
+	case "CAA":
+		return unquote(content)
+	case "TLSA", "SSHFP":
+		if d, err := parseHexRData(rtype, content); err == nil {
+			d.Hex = strings.ToLower(d.Hex)
+			return d.String()
+		}
+	}
+	return content
+}
+
+// contentEqual reports whether two contents of rtype describe the same value.
+func contentEqual(rtype, a, b string) bool {
+	return normalizeContent(rtype, a) == normalizeContent(rtype, b)
+}
+
+// normalizeHostname converts a host name to A-labels, lower-cases it and
+// drops the trailing dot of a fully qualified name.
+func normalizeHostname(s string) string {
+	if s == "." {
+		return s
+	}
+	return strings.ToLower(strings.TrimSuffix(toASCII(s), "."))
+}
+
+// txtValue returns the text of a TXT record. Content made of quoted
+// character-strings ("v=spf1 " "-all") is unquoted and joined; anything else
+// is returned unchanged.
+func txtValue(s string) string {
+	if parts, ok := parseCharacterStrings(s); ok {
+		return strings.Join(parts, "")
+	}
+	return s
+}
+
+// unquote strips one pair of surrounding double quotes, resolving escapes.
+func unquote(s string) string {
+	if parts, ok := parseCharacterStrings(s); ok && len(parts) == 1 {
+		return parts[0]
+	}
+	return s
+}
+
+// parseCharacterStrings parses a sequence of zone-file quoted strings
+// separated by whitespace, resolving \" \\ and \DDD escapes. It reports false
+// when s is not entirely made of quoted strings.
+func parseCharacterStrings(s string) ([]string, bool) {
+	var parts []string
+	i := 0
+	for {
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+			i++
+		}
+		if i == len(s) {
+			break
+		}
+		if s[i] != '"' {
+			return nil, false
+		}
+		i++
+		var b strings.Builder
+		closed := false
+		for i < len(s) {
+			c := s[i]
+			if c == '"' {
+				closed = true
+				i++
+				break
+			}
+			if c == '\\' && i+1 < len(s) {
+				if i+4 <= len(s) && isDigits(s[i+1:i+4]) {
+					v, _ := strconv.Atoi(s[i+1 : i+4])
+					if v > 255 {
+						return nil, false
+					}
+					b.WriteByte(byte(v))
+					i += 4
+					continue
+				}
+				b.WriteByte(s[i+1])
+				i += 2
+				continue
+			}
+			b.WriteByte(c)
+			i++
+		}
+		if !closed {
+			return nil, false
+		}
+		parts = append(parts, b.String())
+	}
+	return parts, len(parts) > 0
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// maxTXTStringLength is the length limit of one TXT character-string.
+const maxTXTStringLength = 255
+
+// splitTXT splits a TXT value into character-strings of at most 255 bytes,
+// never cutting a UTF-8 sequence in half.
+func splitTXT(s string) []string {
+	var parts []string
+	for len(s) > maxTXTStringLength {
+		end := maxTXTStringLength
+		for end > 0 && !utf8.RuneStart(s[end]) {
+			end--
+		}
+		parts = append(parts, s[:end])
+		s = s[end:]
+	}
+	return append(parts, s)
+}
+
+// formatCharacterStrings renders parts as quoted, space-separated
+// character-strings, escaping quotes and backslashes.
+func formatCharacterStrings(parts []string) string {
+	quoted := make([]string, len(parts))
+	for i, p := range parts {
+		p = strings.ReplaceAll(p, `\`, `\\`)
+		quoted[i] = `"` + strings.ReplaceAll(p, `"`, `\"`) + `"`
+	}
+	return strings.Join(quoted, " ")
+}
+
+// txtRequestContent returns the content to send for a TXT value. Values that
+// fit into one character-string and values already written as quoted strings
+// are sent unchanged; longer values are split into quoted 255-byte chunks.
+func txtRequestContent(s string) string {
+	if len(s) <= maxTXTStringLength {
+		return s
+	}
+	if _, ok := parseCharacterStrings(s); ok {
+		return s
+	}
+	return formatCharacterStrings(splitTXT(s))
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContentEqual(t *testing.T) {
+	cases := []struct {
+		rtype, a, b string
+		equal       bool
+	}{
+		{"CNAME", "mail.example.com", "Mail.Example.com.", true},
+		{"MX", "mail.example.com.", "mail.example.com", true},
+		{"CNAME", "mail.example.com", "mail.example.net", false},
+		{"AAAA", "2001:DB8:0:0::1", "2001:db8::1", true},
+		{"AAAA", "2001:db8::1", "2001:db8::2", false},
+		{"A", "10.0.0.1", "10.0.0.1", true},
+		{"TXT", "v=spf1 -all", `"v=spf1 -all"`, true},
+		{"TXT", `"v=spf1 " "-all"`, "v=spf1 -all", true},
+		{"TXT", `"say \"hi\""`, `say "hi"`, true},
+		{"TXT", `"caf\195\169"`, "café", true},
+		{"TXT", "Hello", "hello", false},
+		{"SRV", "5 5060 SIP.example.com.", "5 5060 sip.example.com", true},
+		{"CAA", `"letsencrypt.org"`, "letsencrypt.org", true},
+		{"TLSA", "3 1 1 ABCD", "3 1 1 abcd", true},
+		{"CNAME", "mail.příklad.cz", "mail.xn--pklad-zsa96e.cz.", true},
+	}
+	for _, c := range cases {
+		if got := contentEqual(c.rtype, c.a, c.b); got != c.equal {
+			t.Errorf("contentEqual(%s, %q, %q) = %v, want %v", c.rtype, c.a, c.b, got, c.equal)
+		}
+	}
+}
+
+func TestSplitTXT(t *testing.T) {
+	// 254 ASCII bytes followed by a two-byte rune must not be cut in half
+	s := strings.Repeat("a", 254) + "é" + strings.Repeat("b", 300)
+	parts := splitTXT(s)
+	if len(parts) != 3 || parts[0] != strings.Repeat("a", 254) || strings.Join(parts, "") != s {
+		t.Fatalf("unexpected split: %d parts, first %d bytes", len(parts), len(parts[0]))
+	}
+	for _, p := range parts {
+		if len(p) > maxTXTStringLength {
+			t.Errorf("part of %d bytes exceeds the limit", len(p))
+		}
+	}
+	if got := txtValue(txtRequestContent(s)); got != s {
+		t.Errorf("round trip changed the value")
+	}
+}
+
+func TestIDN(t *testing.T) {
+	cases := []struct{ unicode, ascii string }{
+		{"příklad.cz", "xn--pklad-zsa96e.cz"},
+		{"_dmarc.příklad.cz", "_dmarc.xn--pklad-zsa96e.cz"},
+		{"www.example.com", "www.example.com"},
+	}
+	for _, c := range cases {
+		if got := toASCII(c.unicode); got != c.ascii {
+			t.Errorf("toASCII(%q) = %q, want %q", c.unicode, got, c.ascii)
+		}
+		if got := toUnicode(c.ascii); got != c.unicode {
+			t.Errorf("toUnicode(%q) = %q, want %q", c.ascii, got, c.unicode)
+		}
+	}
+	if got := relativeName("www.xn--pklad-zsa96e.cz", "příklad.cz"); got != "www" {
+		t.Errorf("relativeName = %q, want www", got)
+	}
+}
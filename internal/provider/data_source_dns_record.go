@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure data source implementation
+var _ datasource.DataSource = &dnsRecordDataSource{}
+
+func NewDNSRecordDataSource() datasource.DataSource {
+	return &dnsRecordDataSource{}
+}
+
+type dnsRecordDataSource struct {
+	client DNSAPI
+}
+
+type dnsRecordDataSourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Service  types.String `tfsdk:"service"`
+	Domain   types.String `tfsdk:"domain"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+	CAAValue types.String `tfsdk:"caa_value"`
+	CAAFlags types.Int64  `tfsdk:"caa_flags"`
+	CAATag   types.String `tfsdk:"caa_tag"`
+}
+
+func (d *dnsRecordDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record"
+}
+
+func (d *dnsRecordDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single existing DNS record by name and type.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Record ID assigned by Active24",
+			},
+			"domain": schema.StringAttribute{
+				Required:    true,
+				Description: "Domain name owning the record (zone)",
+			},
+			"service": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Active24 v2 service ID. Resolved from the account's service list by domain when not set",
+				Validators:  serviceValidators(),
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Record name relative to the zone, `@` for the apex. An FQDN inside the zone is also accepted.",
+			},
+			"type": schema.StringAttribute{
+				Required:    true,
+				Description: "DNS record type (A, AAAA, CNAME, TXT, MX, CAA, etc.)",
+			},
+			"content": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Record content. If set, only a record with this content (or CAA value) matches, which disambiguates several records on the same name and type.",
+			},
+			"ttl": schema.Int64Attribute{
+				Computed: true,
+			},
+			"priority": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Priority for MX/SRV where applicable",
+			},
+			"caa_value": schema.StringAttribute{
+				Computed:    true,
+				Description: "Value for CAA record",
+			},
+			"caa_flags": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Flags for CAA record",
+			},
+			"caa_tag": schema.StringAttribute{
+				Computed:    true,
+				Description: "Tag for CAA record",
+			},
+		},
+	}
+}
+
+func (d *dnsRecordDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(DNSAPI)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("Expected provider.DNSAPI, got %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+func (d *dnsRecordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config dnsRecordDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := config.Domain.ValueString()
+	if !resolveServiceAttr(ctx, d.client, config.Domain, &config.Service, &resp.Diagnostics) {
+		return
+	}
+	targetService := config.Service.ValueString()
+	name := relativeName(config.Name.ValueString(), domain)
+	rtype := strings.ToUpper(config.Type.ValueString())
+	content := config.Content.ValueString()
+
+	rec, err := findRecordByNameType(ctx, d.client, domain, targetService, name, rtype, content)
+	if err != nil {
+		var lookupErr *recordLookupError
+		if !errors.As(err, &lookupErr) {
+			resp.Diagnostics.AddError("Error looking up record", err.Error())
+			return
+		}
+		if lookupErr.ambiguous() {
+			resp.Diagnostics.AddError("Multiple records found",
+				fmt.Sprintf("Found %d %s records named '%s'. Set `content` to select one of them.\n\n"+
+					"Matching records:\n%s", len(lookupErr.Matches), rtype, name, lookupErr.matchList()))
+			return
+		}
+		resp.Diagnostics.AddError("Record not found", lookupErr.Error())
+		return
+	}
+
+	config.ID = types.StringValue(fmt.Sprintf("%d", rec.ID))
+	config.TTL = types.Int64Value(rec.TTL)
+	if content == "" {
+		config.Content = types.StringValue(recordContent(*rec))
+	}
+	config.Priority = types.Int64PointerValue(rec.Priority)
+	if strings.EqualFold(rec.Type, "CAA") {
+		caaValue := rec.CAAValue
+		if caaValue == "" {
+			caaValue = rec.Content
+		}
+		config.CAAValue = types.StringValue(caaValue)
+		if rec.Flags != nil {
+			config.CAAFlags = types.Int64Value(*rec.Flags)
+		} else {
+			config.CAAFlags = types.Int64Value(0)
+		}
+		config.CAATag = types.StringValue(rec.Tag)
+	} else {
+		config.CAAValue = types.StringNull()
+		config.CAAFlags = types.Int64Null()
+		config.CAATag = types.StringNull()
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
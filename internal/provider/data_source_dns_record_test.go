@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/JoystiC/terraform-provider-active24/internal/fakeapi"
+)
+
+func TestAccDNSRecordDataSource(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.FQDNNames = true
+	mx := int64(10)
+	flags := int64(0)
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "MX", Content: "mail.example.com", TTL: 3600, Priority: &mx})
+	srv.Put(testAccService, fakeapi.Record{Name: "app", Type: "A", Content: "10.0.0.1", TTL: 300})
+	srv.Put(testAccService, fakeapi.Record{Name: "app", Type: "A", Content: "10.0.0.2", TTL: 300})
+	srv.Put(testAccService, fakeapi.Record{Name: "my-app", Type: "A", Content: "10.0.0.3", TTL: 300})
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "CAA", Content: "letsencrypt.org", CAAValue: "letsencrypt.org", Flags: &flags, Tag: "issue", TTL: 3600})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + fmt.Sprintf(`
+data "active24_dns_record" "mx" {
+  domain  = %[1]q
+  service = %[2]q
+  name    = "@"
+  type    = "MX"
+}
+
+data "active24_dns_record" "app" {
+  domain  = %[1]q
+  service = %[2]q
+  name    = "app.example.com"
+  type    = "A"
+  content = "10.0.0.2"
+}
+
+data "active24_dns_record" "caa" {
+  domain  = %[1]q
+  service = %[2]q
+  name    = "@"
+  type    = "CAA"
+}
+`, testAccDomain, testAccService),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.active24_dns_record.mx", "content", "mail.example.com"),
+					resource.TestCheckResourceAttr("data.active24_dns_record.mx", "priority", "10"),
+					resource.TestCheckResourceAttr("data.active24_dns_record.app", "content", "10.0.0.2"),
+					resource.TestCheckResourceAttr("data.active24_dns_record.app", "ttl", "300"),
+					resource.TestCheckResourceAttr("data.active24_dns_record.caa", "caa_tag", "issue"),
+					resource.TestCheckResourceAttr("data.active24_dns_record.caa", "caa_value", "letsencrypt.org"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + fmt.Sprintf(`
+data "active24_dns_record" "app" {
+  domain  = %q
+  service = %q
+  name    = "app"
+  type    = "A"
+}
+`, testAccDomain, testAccService),
+				ExpectError: regexp.MustCompile("Multiple records found"),
+			},
+			{
+				// A single name+type match must still satisfy content
+				Config: testAccProviderConfig(srv) + fmt.Sprintf(`
+data "active24_dns_record" "mx" {
+  domain  = %q
+  service = %q
+  name    = "@"
+  type    = "MX"
+  content = "other.example.com"
+}
+`, testAccDomain, testAccService),
+				ExpectError: regexp.MustCompile("none matching content"),
+			},
+		},
+	})
+}
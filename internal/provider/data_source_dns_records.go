@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure data source implementation
+var _ datasource.DataSource = &dnsRecordsDataSource{}
+
+func NewDNSRecordsDataSource() datasource.DataSource {
+	return &dnsRecordsDataSource{}
+}
+
+type dnsRecordsDataSource struct {
+	client DNSAPI
+}
+
+type dnsRecordsDataSourceModel struct {
+	ID        types.String       `tfsdk:"id"`
+	Service   types.String       `tfsdk:"service"`
+	Domain    types.String       `tfsdk:"domain"`
+	Name      types.String       `tfsdk:"name"`
+	ExactName types.Bool         `tfsdk:"exact_name"`
+	NameRegex types.String       `tfsdk:"name_regex"`
+	Type      types.String       `tfsdk:"type"`
+	Content   types.String       `tfsdk:"content"`
+	TTL       types.Int64        `tfsdk:"ttl"`
+	Records   []dnsRecordsRecord `tfsdk:"records"`
+}
+
+type dnsRecordsRecord struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+	CAAValue types.String `tfsdk:"caa_value"`
+	CAAFlags types.Int64  `tfsdk:"caa_flags"`
+	CAATag   types.String `tfsdk:"caa_tag"`
+}
+
+func (d *dnsRecordsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_records"
+}
+
+func (d *dnsRecordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists DNS records of a zone, optionally filtered.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"domain": schema.StringAttribute{
+				Required:    true,
+				Description: "Domain name owning the records (zone)",
+			},
+			"service": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Active24 v2 service ID. Resolved from the account's service list by domain when not set",
+				Validators:  serviceValidators(),
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name filter. Matches by substring like the API's filters[name] unless exact_name is true. `@` or the zone name select only the apex records.",
+			},
+			"exact_name": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return records whose relative name equals `name` exactly",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Regular expression (RE2) the relative record name must match. The apex is matched as `@`.",
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Record type filter",
+			},
+			"content": schema.StringAttribute{
+				Optional:    true,
+				Description: "Content filter (substring, as the API's filters[content])",
+			},
+			"ttl": schema.Int64Attribute{
+				Optional:    true,
+				Description: "TTL filter",
+			},
+			"records": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching records ordered by name, type and content",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":        schema.StringAttribute{Computed: true},
+						"name":      schema.StringAttribute{Computed: true, Description: "Name relative to the zone, `@` for the apex"},
+						"type":      schema.StringAttribute{Computed: true},
+						"content":   schema.StringAttribute{Computed: true},
+						"ttl":       schema.Int64Attribute{Computed: true},
+						"priority":  schema.Int64Attribute{Computed: true},
+						"caa_value": schema.StringAttribute{Computed: true},
+						"caa_flags": schema.Int64Attribute{Computed: true},
+						"caa_tag":   schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *dnsRecordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(DNSAPI)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("Expected provider.DNSAPI, got %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+func (d *dnsRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config dnsRecordsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := config.Domain.ValueString()
+	if !resolveServiceAttr(ctx, d.client, config.Domain, &config.Service, &resp.Diagnostics) {
+		return
+	}
+	targetService := config.Service.ValueString()
+
+	var nameRe *regexp.Regexp
+	if !config.NameRegex.IsNull() {
+		re, err := regexp.Compile(config.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid name_regex", err.Error())
+			return
+		}
+		nameRe = re
+	}
+
+	exact := config.ExactName.ValueBool()
+	name := ""
+	if !config.Name.IsNull() {
+		name = relativeName(config.Name.ValueString(), domain)
+		// A substring filter on the empty apex name would match every record
+		if name == "" {
+			exact = true
+		}
+	}
+	if exact && config.Name.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("exact_name"), "Missing name", "`exact_name` requires `name` to be set.")
+		return
+	}
+
+	var ttl *int64
+	if !config.TTL.IsNull() {
+		v := config.TTL.ValueInt64()
+		ttl = &v
+	}
+
+	records, err := d.client.ListRecords(ctx, targetService, name, strings.ToUpper(config.Type.ValueString()), config.Content.ValueString(), ttl)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing records", err.Error())
+		return
+	}
+
+	out := make([]dnsRecordsRecord, 0, len(records))
+	for _, rec := range records {
+		relName := relativeName(rec.Name, domain)
+		if exact && !sameName(relName, name, domain) {
+			continue
+		}
+		recName := denormalizeNameFromAPI(relName)
+		if nameRe != nil && !nameRe.MatchString(recName) {
+			continue
+		}
+		out = append(out, flattenDNSRecordsRecord(rec, recName))
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Name.ValueString() != b.Name.ValueString() {
+			return a.Name.ValueString() < b.Name.ValueString()
+		}
+		if a.Type.ValueString() != b.Type.ValueString() {
+			return a.Type.ValueString() < b.Type.ValueString()
+		}
+		return a.Content.ValueString() < b.Content.ValueString()
+	})
+
+	config.ID = types.StringValue(targetService)
+	config.Records = out
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func flattenDNSRecordsRecord(rec DNSRecord, name string) dnsRecordsRecord {
+	out := dnsRecordsRecord{
+		ID:       types.StringValue(fmt.Sprintf("%d", rec.ID)),
+		Name:     types.StringValue(name),
+		Type:     types.StringValue(rec.Type),
+		Content:  types.StringValue(recordContent(rec)),
+		TTL:      types.Int64Value(rec.TTL),
+		Priority: types.Int64PointerValue(rec.Priority),
+		CAAValue: types.StringNull(),
+		CAAFlags: types.Int64Null(),
+		CAATag:   types.StringNull(),
+	}
+	if strings.EqualFold(rec.Type, "CAA") {
+		caaValue := rec.CAAValue
+		if caaValue == "" {
+			caaValue = rec.Content
+		}
+		out.CAAValue = types.StringValue(caaValue)
+		out.CAAFlags = types.Int64Value(0)
+		if rec.Flags != nil {
+			out.CAAFlags = types.Int64Value(*rec.Flags)
+		}
+		out.CAATag = types.StringValue(rec.Tag)
+	}
+	return out
+}
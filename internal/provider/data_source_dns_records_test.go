@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/JoystiC/terraform-provider-active24/internal/fakeapi"
+)
+
+func TestAccDNSRecordsDataSource(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.FQDNNames = true
+	srv.PageSize = 2
+	srv.Put(testAccService, fakeapi.Record{Name: "_dmarc", Type: "TXT", Content: "v=DMARC1; p=none", TTL: 3600})
+	srv.Put(testAccService, fakeapi.Record{Name: "_dmarc.shop", Type: "TXT", Content: "v=DMARC1; p=reject", TTL: 3600})
+	srv.Put(testAccService, fakeapi.Record{Name: "www", Type: "A", Content: "10.0.0.1", TTL: 300})
+	srv.Put(testAccService, fakeapi.Record{Name: "api", Type: "A", Content: "10.0.0.2", TTL: 300})
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "A", Content: "10.0.0.3", TTL: 3600})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + fmt.Sprintf(`
+data "active24_dns_records" "dmarc" {
+  domain     = %[1]q
+  service    = %[2]q
+  name       = "_dmarc"
+  exact_name = true
+  type       = "TXT"
+}
+
+data "active24_dns_records" "dmarc_upper" {
+  domain     = %[1]q
+  service    = %[2]q
+  name       = "_DMARC"
+  exact_name = true
+}
+
+data "active24_dns_records" "a_300" {
+  domain  = %[1]q
+  service = %[2]q
+  type    = "A"
+  ttl     = 300
+}
+
+data "active24_dns_records" "apex" {
+  domain  = %[1]q
+  service = %[2]q
+  name    = "@"
+}
+
+data "active24_dns_records" "regex" {
+  domain     = %[1]q
+  service    = %[2]q
+  name_regex = "^(@|www)$"
+}
+`, testAccDomain, testAccService),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.active24_dns_records.dmarc", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.active24_dns_records.dmarc", "records.0.content", "v=DMARC1; p=none"),
+					resource.TestCheckResourceAttr("data.active24_dns_records.dmarc_upper", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.active24_dns_records.dmarc_upper", "records.0.name", "_dmarc"),
+					resource.TestCheckResourceAttr("data.active24_dns_records.a_300", "records.#", "2"),
+					resource.TestCheckResourceAttr("data.active24_dns_records.a_300", "records.0.name", "api"),
+					resource.TestCheckResourceAttr("data.active24_dns_records.a_300", "records.1.name", "www"),
+					resource.TestCheckResourceAttr("data.active24_dns_records.apex", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.active24_dns_records.apex", "records.0.content", "10.0.0.3"),
+					resource.TestCheckResourceAttr("data.active24_dns_records.regex", "records.#", "2"),
+					resource.TestCheckResourceAttr("data.active24_dns_records.regex", "records.0.name", "@"),
+				),
+			},
+		},
+	})
+}
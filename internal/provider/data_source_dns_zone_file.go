@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure data source implementation
+var _ datasource.DataSource = &dnsZoneFileDataSource{}
+
+func NewDNSZoneFileDataSource() datasource.DataSource {
+	return &dnsZoneFileDataSource{}
+}
+
+type dnsZoneFileDataSource struct {
+	client DNSAPI
+}
+
+type dnsZoneFileDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Service     types.String `tfsdk:"service"`
+	Domain      types.String `tfsdk:"domain"`
+	Content     types.String `tfsdk:"content"`
+	RecordCount types.Int64  `tfsdk:"record_count"`
+}
+
+func (d *dnsZoneFileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_file"
+}
+
+func (d *dnsZoneFileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exports a zone as an RFC 1035 master file (BIND zone file).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"domain": schema.StringAttribute{
+				Required:    true,
+				Description: "Domain name of the zone, used as $ORIGIN",
+			},
+			"service": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Active24 v2 service ID. Resolved from the account's service list by domain when not set",
+				Validators:  serviceValidators(),
+			},
+			"content": schema.StringAttribute{
+				Computed:    true,
+				Description: "Zone file text with $ORIGIN and $TTL, one record per line in a stable order",
+			},
+			"record_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of records in the zone file",
+			},
+		},
+	}
+}
+
+func (d *dnsZoneFileDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(DNSAPI)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("Expected provider.DNSAPI, got %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+func (d *dnsZoneFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config dnsZoneFileDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := config.Domain.ValueString()
+	if !resolveServiceAttr(ctx, d.client, config.Domain, &config.Service, &resp.Diagnostics) {
+		return
+	}
+	targetService := config.Service.ValueString()
+
+	records, err := d.client.ListRecords(ctx, targetService, "", "", "", nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing records", err.Error())
+		return
+	}
+	zone := make([]zoneRecord, 0, len(records))
+	for _, rec := range records {
+		zone = append(zone, zoneRecordFromAPI(rec, domain))
+	}
+
+	config.ID = types.StringValue(targetService)
+	config.Content = types.StringValue(renderZoneFile(domain, zone))
+	config.RecordCount = types.Int64Value(int64(len(zone)))
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/JoystiC/terraform-provider-active24/internal/fakeapi"
+)
+
+func TestAccDNSZoneFileDataSource(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.Put(testAccService, fakeapi.Record{Name: "www", Type: "A", Content: "10.0.0.1", TTL: 300})
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "MX", Content: "mail.example.com", TTL: 3600, Priority: ptrI(10)})
+	srv.Put(testAccService, fakeapi.Record{Name: "_sip._tcp", Type: "SRV", Content: "pbx.example.com", TTL: 3600, Priority: ptrI(20), Weight: ptrI(5), Port: ptrI(5060)})
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "TXT", Content: `v=spf1 include:"x" -all`, TTL: 3600})
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "CAA", CAAValue: "letsencrypt.org", Flags: ptrI(0), Tag: "issue", TTL: 3600})
+
+	want := `$ORIGIN example.com.
+$TTL 3600
+@             IN CAA 0 issue "letsencrypt.org"
+@             IN MX  10 mail.example.com.
+@             IN TXT "v=spf1 include:\"x\" -all"
+_sip._tcp     IN SRV 20 5 5060 pbx.example.com.
+www       300 IN A   10.0.0.1
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + fmt.Sprintf(`
+data "active24_dns_zone_file" "test" {
+  domain = %q
+}
+`, testAccDomain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.active24_dns_zone_file.test", "service", testAccService),
+					resource.TestCheckResourceAttr("data.active24_dns_zone_file.test", "record_count", "5"),
+					resource.TestCheckResourceAttr("data.active24_dns_zone_file.test", "content", want),
+				),
+			},
+		},
+	})
+}
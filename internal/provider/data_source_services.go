@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure data source implementation
+var _ datasource.DataSource = &servicesDataSource{}
+
+func NewServicesDataSource() datasource.DataSource {
+	return &servicesDataSource{}
+}
+
+type servicesDataSource struct {
+	client DNSAPI
+}
+
+type servicesDataSourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	Type      types.String   `tfsdk:"type"`
+	Name      types.String   `tfsdk:"name"`
+	NameRegex types.String   `tfsdk:"name_regex"`
+	Services  []servicesItem `tfsdk:"services"`
+}
+
+type servicesItem struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`
+	Status         types.String `tfsdk:"status"`
+	ExpirationDate types.String `tfsdk:"expiration_date"`
+}
+
+func (d *servicesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_services"
+}
+
+func (d *servicesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the services on the Active24 account, optionally filtered by type and name.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Service type filter, e.g. `dns`, `domain`, `hosting` or `mail` (case-insensitive)",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return services whose name (usually the domain) equals this value. Unicode and punycode spellings match.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Regular expression (RE2) the service name must match",
+			},
+			"services": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching services ordered by ID",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":              schema.StringAttribute{Computed: true, Description: "Service ID, usable as `service` of the DNS resources"},
+						"name":            schema.StringAttribute{Computed: true, Description: "Service name, usually the domain"},
+						"type":            schema.StringAttribute{Computed: true, Description: "Service type as reported by Active24, e.g. `domain`"},
+						"status":          schema.StringAttribute{Computed: true},
+						"expiration_date": schema.StringAttribute{Computed: true, Description: "Expiration date as reported by Active24, null when the service does not expire"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *servicesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(DNSAPI)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("Expected provider.DNSAPI, got %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+func (d *servicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config servicesDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRe *regexp.Regexp
+	if !config.NameRegex.IsNull() {
+		re, err := regexp.Compile(config.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid name_regex", err.Error())
+			return
+		}
+		nameRe = re
+	}
+
+	services, err := d.client.ListServices(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing services", err.Error())
+		return
+	}
+
+	sort.SliceStable(services, func(i, j int) bool { return services[i].ID < services[j].ID })
+	out := make([]servicesItem, 0, len(services))
+	for _, svc := range services {
+		if !config.Type.IsNull() && !strings.EqualFold(svc.Type, config.Type.ValueString()) {
+			continue
+		}
+		if !config.Name.IsNull() && !sameName(svc.Name, config.Name.ValueString(), "") {
+			continue
+		}
+		if nameRe != nil && !nameRe.MatchString(svc.Name) {
+			continue
+		}
+		out = append(out, flattenService(svc))
+	}
+
+	// The list is account-wide, there is no natural ID
+	config.ID = types.StringValue("services")
+	config.Services = out
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func flattenService(svc Service) servicesItem {
+	out := servicesItem{
+		ID:             types.StringValue(fmt.Sprintf("%d", svc.ID)),
+		Name:           types.StringValue(svc.Name),
+		Type:           types.StringValue(svc.Type),
+		Status:         types.StringValue(svc.Status),
+		ExpirationDate: types.StringNull(),
+	}
+	if svc.ExpirationDate != "" {
+		out.ExpirationDate = types.StringValue(svc.ExpirationDate)
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/JoystiC/terraform-provider-active24/internal/fakeapi"
+)
+
+func TestAccServicesDataSource(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.AddAccountService(fakeapi.Service{ID: 11111111, Name: "example.com", ServiceName: "hosting", Status: "active", ExpirationDate: "2027-01-31"})
+	srv.AddAccountService(fakeapi.Service{ID: 22222222, Name: "example.net", ServiceName: "domain", Status: "expired", ExpirationDate: "2025-06-30"})
+	srv.AddAccountService(fakeapi.Service{ID: 33333333, Name: "info@example.com", ServiceName: "mail", Status: "active"})
+	srv.AddService("44444444", "xn--pklad-zsa96e.cz")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "active24_services" "all" {}
+
+data "active24_services" "domains" {
+  type = "Domain"
+}
+
+data "active24_services" "example_com" {
+  name = "example.com"
+}
+
+data "active24_services" "idn" {
+  name = "příklad.cz"
+}
+
+data "active24_services" "regex" {
+  name_regex = "^example\\.(com|net)$"
+  type       = "domain"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.active24_services.all", "services.#", "5"),
+					resource.TestCheckResourceAttr("data.active24_services.all", "services.0.id", "11111111"),
+					resource.TestCheckResourceAttr("data.active24_services.all", "services.0.type", "hosting"),
+					resource.TestCheckResourceAttr("data.active24_services.all", "services.0.expiration_date", "2027-01-31"),
+					resource.TestCheckNoResourceAttr("data.active24_services.all", "services.3.expiration_date"),
+					resource.TestCheckResourceAttr("data.active24_services.domains", "services.#", "3"),
+					resource.TestCheckResourceAttr("data.active24_services.example_com", "services.#", "2"),
+					resource.TestCheckResourceAttr("data.active24_services.example_com", "services.1.id", testAccService),
+					resource.TestCheckResourceAttr("data.active24_services.idn", "services.#", "1"),
+					resource.TestCheckResourceAttr("data.active24_services.idn", "services.0.id", "44444444"),
+					resource.TestCheckResourceAttr("data.active24_services.regex", "services.#", "2"),
+					resource.TestCheckResourceAttr("data.active24_services.regex", "services.1.status", "expired"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// APIError is returned by Client for any non-2xx response from the Active24 API.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Method     string
+	Path       string
+
+	// Message is the human readable summary from the error body (title/detail/message).
+	Message string
+	// FieldErrors holds validation messages keyed by API field name (e.g. "content", "caaValue").
+	FieldErrors map[string][]string
+	// Body is the raw response body.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = e.Body
+	}
+	s := fmt.Sprintf("active24 API error: %s %s: %s", e.Method, e.Path, e.Status)
+	if msg != "" {
+		s += ": " + msg
+	}
+	for _, field := range e.fieldNames() {
+		s += fmt.Sprintf("\n  %s: %s", field, strings.Join(e.FieldErrors[field], "; "))
+	}
+	return s
+}
+
+func (e *APIError) fieldNames() []string {
+	names := make([]string, 0, len(e.FieldErrors))
+	for k := range e.FieldErrors {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// apiErrorBody covers the error payload shapes returned by Active24 v2:
+// RFC 7807 problem details with invalidParameters, and the older
+// {"message": ..., "errors": {field: [...]}} form.
+type apiErrorBody struct {
+	Title             string          `json:"title"`
+	Detail            string          `json:"detail"`
+	Message           string          `json:"message"`
+	Error             string          `json:"error"`
+	InvalidParameters []invalidParam  `json:"invalidParameters"`
+	Errors            json.RawMessage `json:"errors"`
+}
+
+type invalidParam struct {
+	Name    string `json:"name"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+func newAPIError(method string, req *http.Request, resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Method:     method,
+		Path:       req.URL.Path,
+		Body:       string(body),
+	}
+
+	var parsed apiErrorBody
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return e
+	}
+	switch {
+	case parsed.Title != "" && parsed.Detail != "":
+		e.Message = parsed.Title + ": " + parsed.Detail
+	case parsed.Detail != "":
+		e.Message = parsed.Detail
+	case parsed.Title != "":
+		e.Message = parsed.Title
+	case parsed.Message != "":
+		e.Message = parsed.Message
+	case parsed.Error != "":
+		e.Message = parsed.Error
+	}
+
+	add := func(field, msg string) {
+		if field == "" || msg == "" {
+			return
+		}
+		if e.FieldErrors == nil {
+			e.FieldErrors = map[string][]string{}
+		}
+		e.FieldErrors[field] = append(e.FieldErrors[field], msg)
+	}
+	for _, p := range parsed.InvalidParameters {
+		msg := p.Reason
+		if msg == "" {
+			msg = p.Message
+		}
+		add(p.Name, msg)
+	}
+	if len(parsed.Errors) > 0 {
+		// errors may be {field: "msg"}, {field: ["msg", ...]} or [{name, reason}]
+		var byField map[string]json.RawMessage
+		if json.Unmarshal(parsed.Errors, &byField) == nil {
+			for field, raw := range byField {
+				var one string
+				var many []string
+				if json.Unmarshal(raw, &one) == nil {
+					add(field, one)
+				} else if json.Unmarshal(raw, &many) == nil {
+					for _, m := range many {
+						add(field, m)
+					}
+				}
+			}
+		} else {
+			var list []invalidParam
+			if json.Unmarshal(parsed.Errors, &list) == nil {
+				for _, p := range list {
+					msg := p.Reason
+					if msg == "" {
+						msg = p.Message
+					}
+					add(p.Name, msg)
+				}
+			}
+		}
+	}
+	return e
+}
+
+func statusOf(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is an API 404 response.
+func IsNotFound(err error) bool {
+	return statusOf(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is an API 401 or 403 response.
+func IsUnauthorized(err error) bool {
+	s := statusOf(err)
+	return s == http.StatusUnauthorized || s == http.StatusForbidden
+}
+
+// IsRateLimited reports whether err is an API 429 response.
+func IsRateLimited(err error) bool {
+	return statusOf(err) == http.StatusTooManyRequests
+}
+
+// IsValidation reports whether err is an API 400 or 422 response, i.e. the
+// request payload was rejected.
+func IsValidation(err error) bool {
+	s := statusOf(err)
+	return s == http.StatusBadRequest || s == http.StatusUnprocessableEntity
+}
+
+// appendAPIError adds err to diags. Validation messages for API fields that
+// map to a Terraform attribute (via fieldPaths) are attached to that attribute
+// path; everything else is reported as a general error.
+func appendAPIError(diags *diag.Diagnostics, summary string, err error, fieldPaths map[string]path.Path) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || len(apiErr.FieldErrors) == 0 {
+		diags.AddError(summary, err.Error())
+		return
+	}
+
+	var unmapped []string
+	for _, field := range apiErr.fieldNames() {
+		msgs := strings.Join(apiErr.FieldErrors[field], "; ")
+		if p, ok := fieldPaths[field]; ok {
+			diags.AddAttributeError(p, summary, fmt.Sprintf("Active24 rejected %s: %s", field, msgs))
+			continue
+		}
+		unmapped = append(unmapped, fmt.Sprintf("%s: %s", field, msgs))
+	}
+	if len(unmapped) > 0 {
+		detail := apiErr.Message
+		if detail == "" {
+			detail = apiErr.Status
+		}
+		diags.AddError(summary, detail+"\n"+strings.Join(unmapped, "\n"))
+	}
+}
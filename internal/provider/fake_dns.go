@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FakeDNS is an in-memory DNSAPI. It mimics the Active24 behaviours the
+// provider depends on: record IDs are assigned on create, unknown IDs return a
+// 404 APIError, and the name/content list filters match by substring.
+type FakeDNS struct {
+	mu      sync.Mutex
+	nextID  int64
+	records map[string]map[int64]DNSRecord // service -> id -> record
+
+	// Services is the account's service list returned by ListServices.
+	Services []Service
+
+	// Fail, when set, is called before every operation with the operation name
+	// ("create", "get", "update", "delete", "list", "services") and the
+	// service, which is empty for "services". A non-nil
+	// return value is returned to the caller instead of performing the operation.
+	Fail func(op, service string) error
+}
+
+var _ DNSAPI = (*FakeDNS)(nil)
+
+// NewFakeDNS returns an empty FakeDNS.
+func NewFakeDNS() *FakeDNS {
+	return &FakeDNS{nextID: 1000, records: map[string]map[int64]DNSRecord{}}
+}
+
+// Put stores rec under service as if it had been created out of band. A zero
+// ID is replaced by a newly assigned one. It returns the stored record.
+func (f *FakeDNS) Put(service string, rec DNSRecord) DNSRecord {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if rec.ID == 0 {
+		rec.ID = f.newID()
+	}
+	f.zone(service)[rec.ID] = rec
+	return rec
+}
+
+// Records returns all records of service ordered by ID.
+func (f *FakeDNS) Records(service string) []DNSRecord {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sorted(service)
+}
+
+func (f *FakeDNS) CreateRecord(_ context.Context, service string, req RecordRequest) (*DNSRecord, error) {
+	if err := f.fail("create", service); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rec := recordFromRequest(f.newID(), req)
+	f.zone(service)[rec.ID] = rec
+	return &rec, nil
+}
+
+func (f *FakeDNS) GetRecord(_ context.Context, service string, id int64) (*DNSRecord, error) {
+	if err := f.fail("get", service); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rec, ok := f.zone(service)[id]
+	if !ok {
+		return nil, fakeNotFound(http.MethodGet, service, id)
+	}
+	return &rec, nil
+}
+
+func (f *FakeDNS) UpdateRecord(_ context.Context, service string, id int64, req RecordRequest) (*DNSRecord, error) {
+	if err := f.fail("update", service); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	zone := f.zone(service)
+	if _, ok := zone[id]; !ok {
+		return nil, fakeNotFound(http.MethodPut, service, id)
+	}
+	rec := recordFromRequest(id, req)
+	zone[id] = rec
+	return &rec, nil
+}
+
+func (f *FakeDNS) DeleteRecord(_ context.Context, service string, id int64) error {
+	if err := f.fail("delete", service); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	zone := f.zone(service)
+	if _, ok := zone[id]; !ok {
+		return fakeNotFound(http.MethodDelete, service, id)
+	}
+	delete(zone, id)
+	return nil
+}
+
+func (f *FakeDNS) ListRecords(_ context.Context, service string, name string, rtype string, content string, ttl *int64) ([]DNSRecord, error) {
+	if err := f.fail("list", service); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []DNSRecord
+	for _, rec := range f.sorted(service) {
+		if name != "" && !strings.Contains(rec.Name, name) {
+			continue
+		}
+		if rtype != "" && !strings.EqualFold(rec.Type, rtype) {
+			continue
+		}
+		if content != "" && !strings.Contains(rec.Content, content) {
+			continue
+		}
+		if ttl != nil && rec.TTL != *ttl {
+			continue
+		}
+		out = append(out, rec)
+	}
+	return out, nil
+}
+
+func (f *FakeDNS) ListServices(_ context.Context) ([]Service, error) {
+	if err := f.fail("services", ""); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Service(nil), f.Services...), nil
+}
+
+func (f *FakeDNS) ResolveService(ctx context.Context, domain string) (string, error) {
+	services, err := f.ListServices(ctx)
+	if err != nil {
+		return "", err
+	}
+	return resolveService(services, domain)
+}
+
+func (f *FakeDNS) fail(op, service string) error {
+	if f.Fail == nil {
+		return nil
+	}
+	return f.Fail(op, service)
+}
+
+func (f *FakeDNS) newID() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *FakeDNS) zone(service string) map[int64]DNSRecord {
+	z, ok := f.records[service]
+	if !ok {
+		z = map[int64]DNSRecord{}
+		f.records[service] = z
+	}
+	return z
+}
+
+func (f *FakeDNS) sorted(service string) []DNSRecord {
+	zone := f.records[service]
+	out := make([]DNSRecord, 0, len(zone))
+	for _, rec := range zone {
+		out = append(out, rec)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func recordFromRequest(id int64, req RecordRequest) DNSRecord {
+	return DNSRecord{
+		ID:       id,
+		Name:     req.Name,
+		Type:     req.Type,
+		Content:  req.Content,
+		TTL:      req.TTL,
+		Priority: req.Priority,
+		CAAValue: req.CAAValue,
+		Flags:    req.Flags,
+		Tag:      req.Tag,
+		Weight:   req.Weight,
+		Port:     req.Port,
+	}
+}
+
+func fakeNotFound(method, service string, id int64) *APIError {
+	return &APIError{
+		StatusCode: http.StatusNotFound,
+		Status:     "404 Not Found",
+		Method:     method,
+		Path:       fmt.Sprintf("/v2/service/%s/dns/record/%d", service, id),
+		Message:    "Record not found",
+	}
+}
@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func newTestFakeDNS() *FakeDNS {
+	f := NewFakeDNS()
+	f.Services = []Service{{ID: 12345678, Name: testAccDomain, Type: "domain", Status: "active"}}
+	return f
+}
+
+// TestDNSAPIContract runs the same scenario against FakeDNS and the real
+// client talking to fakeapi, so tests built on FakeDNS see the behaviour of
+// the API.
+func TestDNSAPIContract(t *testing.T) {
+	impls := map[string]func(t *testing.T) DNSAPI{
+		"FakeDNS": func(*testing.T) DNSAPI { return newTestFakeDNS() },
+		"Client":  func(t *testing.T) DNSAPI { return newTestClient(t, newTestAccServer(t)) },
+	}
+	for name, newAPI := range impls {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			api := newAPI(t)
+
+			service, err := api.ResolveService(ctx, testAccDomain)
+			if err != nil || service != testAccService {
+				t.Fatalf("ResolveService = %q, %v; want %q", service, err, testAccService)
+			}
+
+			www, err := api.CreateRecord(ctx, service, RecordRequest{Name: "www", Type: "A", Content: "10.0.0.1", TTL: 300})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if www.ID == 0 {
+				t.Fatal("CreateRecord returned no ID")
+			}
+			for _, req := range []RecordRequest{
+				{Name: "www2", Type: "A", Content: "10.0.0.2", TTL: 300},
+				{Name: "mail", Type: "MX", Content: "mx.example.com", TTL: 3600, Priority: ptrI(10)},
+			} {
+				if _, err := api.CreateRecord(ctx, service, req); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := api.GetRecord(ctx, service, www.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != "www" || got.Type != "A" || got.Content != "10.0.0.1" || got.TTL != 300 {
+				t.Errorf("GetRecord = %+v", got)
+			}
+
+			// Name and content filter by substring, type and TTL exactly
+			for _, tc := range []struct {
+				name, rtype, content string
+				ttl                  *int64
+				want                 int
+			}{
+				{"", "", "", nil, 3},
+				{"www", "", "", nil, 2},
+				{"", "A", "", nil, 2},
+				{"", "", "10.0.0", nil, 2},
+				{"", "", "", ptrI(3600), 1},
+				{"www", "MX", "", nil, 0},
+			} {
+				records, err := api.ListRecords(ctx, service, tc.name, tc.rtype, tc.content, tc.ttl)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(records) != tc.want {
+					t.Errorf("ListRecords(%q, %q, %q) returned %d records, want %d", tc.name, tc.rtype, tc.content, len(records), tc.want)
+				}
+			}
+
+			updated, err := api.UpdateRecord(ctx, service, www.ID, RecordRequest{Name: "www", Type: "A", Content: "10.0.0.9", TTL: 600})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated.ID != www.ID || updated.Content != "10.0.0.9" || updated.TTL != 600 {
+				t.Errorf("UpdateRecord = %+v", updated)
+			}
+
+			if err := api.DeleteRecord(ctx, service, www.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := api.GetRecord(ctx, service, www.ID); !IsNotFound(err) {
+				t.Errorf("GetRecord after delete: want not found, got %v", err)
+			}
+			if _, err := api.UpdateRecord(ctx, service, www.ID, RecordRequest{Name: "www", Type: "A", Content: "10.0.0.1", TTL: 300}); !IsNotFound(err) {
+				t.Errorf("UpdateRecord after delete: want not found, got %v", err)
+			}
+			if err := api.DeleteRecord(ctx, service, www.ID); !IsNotFound(err) {
+				t.Errorf("DeleteRecord after delete: want not found, got %v", err)
+			}
+		})
+	}
+}
+
+// TestDNSRecordResourceFake drives the CRUD and import methods of
+// active24_dns_record directly against FakeDNS.
+func TestDNSRecordResourceFake(t *testing.T) {
+	ctx := context.Background()
+	fake := newTestFakeDNS()
+	r := &dnsRecordResource{client: fake}
+
+	// Create resolves the service and stores the assigned ID
+	plan := testFakeRecordModel("www", "A", "10.0.0.1", 300)
+	plan.Service = types.StringUnknown()
+	createResp := resource.CreateResponse{State: testFakeRecordState(t, r, nil)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(testFakeRecordState(t, r, &plan))}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: %v", createResp.Diagnostics)
+	}
+	var state dnsRecordModel
+	createResp.State.Get(ctx, &state)
+	records := fake.Records(testAccService)
+	if len(records) != 1 || state.ID.ValueString() != strconv.FormatInt(records[0].ID, 10) {
+		t.Fatalf("Create stored ID %s, zone has %+v", state.ID, records)
+	}
+	if state.Service.ValueString() != testAccService {
+		t.Errorf("Create stored service %s, want %s", state.Service, testAccService)
+	}
+	id := records[0].ID
+
+	// Read picks up an out-of-band change
+	rec := records[0]
+	rec.TTL = 600
+	fake.Put(testAccService, rec)
+	state = testFakeRead(t, r, state)
+	if state.TTL.ValueInt64() != 600 {
+		t.Errorf("Read TTL = %s, want 600", state.TTL)
+	}
+
+	// Update changes the record in place
+	plan = state
+	plan.Content = types.StringValue("10.0.0.2")
+	updateResp := resource.UpdateResponse{State: testFakeRecordState(t, r, &state)}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:  tfsdk.Plan(testFakeRecordState(t, r, &plan)),
+		State: testFakeRecordState(t, r, &state),
+	}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: %v", updateResp.Diagnostics)
+	}
+	updateResp.State.Get(ctx, &state)
+	if got, err := fake.GetRecord(ctx, testAccService, id); err != nil || got.Content != "10.0.0.2" {
+		t.Errorf("after Update: %+v, %v", got, err)
+	}
+
+	// Import by name and type finds the record
+	importResp := resource.ImportStateResponse{State: testFakeRecordState(t, r, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: testAccDomain + ":www:A"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("ImportState: %v", importResp.Diagnostics)
+	}
+	var imported dnsRecordModel
+	importResp.State.Get(ctx, &imported)
+	if imported.ID.ValueString() != strconv.FormatInt(id, 10) || imported.Service.ValueString() != testAccService {
+		t.Errorf("ImportState stored ID %s, service %s", imported.ID, imported.Service)
+	}
+
+	// A server error keeps the record in state
+	fake.Fail = func(op, _ string) error {
+		return &APIError{StatusCode: http.StatusInternalServerError, Status: "500 Internal Server Error", Method: http.MethodGet}
+	}
+	readResp := resource.ReadResponse{State: testFakeRecordState(t, r, &state)}
+	r.Read(ctx, resource.ReadRequest{State: testFakeRecordState(t, r, &state)}, &readResp)
+	if !readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		t.Errorf("Read with API error: diagnostics %v, state removed %v", readResp.Diagnostics, readResp.State.Raw.IsNull())
+	}
+	fake.Fail = nil
+
+	// Delete removes the record and tolerates a record that is already gone
+	for i := 0; i < 2; i++ {
+		deleteResp := resource.DeleteResponse{State: testFakeRecordState(t, r, &state)}
+		r.Delete(ctx, resource.DeleteRequest{State: testFakeRecordState(t, r, &state)}, &deleteResp)
+		if deleteResp.Diagnostics.HasError() {
+			t.Fatalf("Delete #%d: %v", i+1, deleteResp.Diagnostics)
+		}
+	}
+	if _, err := fake.GetRecord(ctx, testAccService, id); !IsNotFound(err) {
+		t.Errorf("record still exists after Delete: %v", err)
+	}
+
+	// Read of a deleted record removes it from state
+	readResp = resource.ReadResponse{State: testFakeRecordState(t, r, &state)}
+	r.Read(ctx, resource.ReadRequest{State: testFakeRecordState(t, r, &state)}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("Read of deleted record: diagnostics %v, state removed %v", readResp.Diagnostics, readResp.State.Raw.IsNull())
+	}
+}
+
+func TestFakeDNSFail(t *testing.T) {
+	fake := newTestFakeDNS()
+	errDown := errors.New("down")
+	var ops []string
+	fake.Fail = func(op, service string) error {
+		ops = append(ops, op+" "+service)
+		return errDown
+	}
+	ctx := context.Background()
+	if _, err := fake.CreateRecord(ctx, testAccService, RecordRequest{Name: "www", Type: "A", Content: "10.0.0.1", TTL: 300}); !errors.Is(err, errDown) {
+		t.Errorf("CreateRecord error = %v", err)
+	}
+	if _, err := fake.ResolveService(ctx, testAccDomain); !errors.Is(err, errDown) {
+		t.Errorf("ResolveService error = %v", err)
+	}
+	if len(fake.Records(testAccService)) != 0 {
+		t.Error("failed create stored a record")
+	}
+	if want := []string{"create " + testAccService, "services "}; len(ops) != 2 || ops[0] != want[0] || ops[1] != want[1] {
+		t.Errorf("Fail called with %q, want %q", ops, want)
+	}
+}
+
+// testFakeRecordModel returns a planned record with every optional attribute
+// null. name_unicode and fqdn are known, as their plan modifiers compute them.
+func testFakeRecordModel(name, rtype, content string, ttl int64) dnsRecordModel {
+	return dnsRecordModel{
+		ID:          types.StringUnknown(),
+		Service:     types.StringValue(testAccService),
+		Domain:      types.StringValue(testAccDomain),
+		Name:        types.StringValue(name),
+		Type:        types.StringValue(rtype),
+		Content:     types.StringValue(content),
+		TTL:         types.Int64Value(ttl),
+		TXTStrings:  types.ListNull(types.StringType),
+		NameUnicode: types.StringValue(name),
+		FQDN:        types.StringValue(fqdn(name, testAccDomain)),
+		Timeouts: timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType, "read": types.StringType, "update": types.StringType, "delete": types.StringType,
+		})},
+	}
+}
+
+// testFakeRecordState returns a state of the resource schema holding m, or
+// a null state when m is nil.
+func testFakeRecordState(t *testing.T, r *dnsRecordResource, m *dnsRecordModel) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if m != nil {
+		if diags := state.Set(ctx, m); diags.HasError() {
+			t.Fatalf("building state: %v", diags)
+		}
+	}
+	return state
+}
+
+func testFakeRead(t *testing.T, r *dnsRecordResource, state dnsRecordModel) dnsRecordModel {
+	t.Helper()
+	resp := resource.ReadResponse{State: testFakeRecordState(t, r, &state)}
+	r.Read(context.Background(), resource.ReadRequest{State: testFakeRecordState(t, r, &state)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+	var out dnsRecordModel
+	resp.State.Get(context.Background(), &out)
+	return out
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Provider-defined functions (Terraform 1.8+) expose the normalization the
+// resources apply, so modules can compute the same names and values the
+// provider stores. All functions are pure and need no provider configuration.
+
+var (
+	_ function.Function = fqdnFunction{}
+	_ function.Function = relativeNameFunction{}
+	_ function.Function = parseZoneFileFunction{}
+	_ function.Function = splitTXTFunction{}
+	_ function.Function = caaFunction{}
+)
+
+func NewFQDNFunction() function.Function { return fqdnFunction{} }
+
+type fqdnFunction struct{}
+
+func (f fqdnFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "fqdn"
+}
+
+func (f fqdnFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Fully qualified record name",
+		Description: "Returns the fully qualified name of a record in punycode (A-label) form without the trailing dot, the value of the `fqdn` attribute of `active24_dns_record`. `name` may be relative, `@` or already fully qualified.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "name", Description: "Record name"},
+			function.StringParameter{Name: "domain", Description: "Zone name"},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f fqdnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name, domain string
+	resp.Error = req.Arguments.Get(ctx, &name, &domain)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, fqdn(name, domain))
+}
+
+func NewRelativeNameFunction() function.Function { return relativeNameFunction{} }
+
+type relativeNameFunction struct{}
+
+func (f relativeNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "relative_name"
+}
+
+func (f relativeNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Record name relative to the zone",
+		Description: "Returns a record name relative to the zone in punycode (A-label) form, `@` for the apex, as `active24_dns_record` reads it from the API. A name outside the zone is returned without its trailing dot.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "fqdn", Description: "Record name, usually fully qualified"},
+			function.StringParameter{Name: "domain", Description: "Zone name"},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f relativeNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name, domain string
+	resp.Error = req.Arguments.Get(ctx, &name, &domain)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, displayName(name, domain))
+}
+
+func NewParseZoneFileFunction() function.Function { return parseZoneFileFunction{} }
+
+type parseZoneFileFunction struct{}
+
+func (f parseZoneFileFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_zone_file"
+}
+
+func (f parseZoneFileFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a BIND zone file",
+		Description: "Parses an RFC 1035 master file into a list of records in the form of the `records` attribute of `active24_dns_zone_records`. " +
+			"The zone is taken from the first `$ORIGIN` directive, and SOA and apex NS records are skipped. " +
+			"The syntax is the same as for `active24_dns_zone_file_records`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "text", Description: "Zone file text starting with $ORIGIN"},
+		},
+		Return: function.ListReturn{ElementType: zoneRecordObjectType},
+	}
+}
+
+func (f parseZoneFileFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var text string
+	resp.Error = req.Arguments.Get(ctx, &text)
+	if resp.Error != nil {
+		return
+	}
+	domain, err := zoneFileOrigin(text)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	parsed, err := parseZoneFile(text, domain)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	records := make([]dnsZoneRecordModel, len(parsed))
+	for i, z := range parsed {
+		records[i] = zoneRecordModelFromAPI(z, dnsZoneRecordModel{}, false)
+	}
+	resp.Error = resp.Result.Set(ctx, records)
+}
+
+func NewSplitTXTFunction() function.Function { return splitTXTFunction{} }
+
+type splitTXTFunction struct{}
+
+func (f splitTXTFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "split_txt"
+}
+
+func (f splitTXTFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Split a TXT value into character-strings",
+		Description: "Returns the character-strings a TXT `content` is sent as: content written as quoted strings is unquoted, longer values are split into strings of at most 255 bytes without cutting a UTF-8 character. The result can be used as `txt_strings`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "content", Description: "TXT record content"},
+		},
+		Return: function.ListReturn{ElementType: types.StringType},
+	}
+}
+
+func (f splitTXTFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content string
+	resp.Error = req.Arguments.Get(ctx, &content)
+	if resp.Error != nil {
+		return
+	}
+	parts, ok := parseCharacterStrings(content)
+	if !ok {
+		parts = splitTXT(content)
+	}
+	resp.Error = resp.Result.Set(ctx, parts)
+}
+
+func NewCAAFunction() function.Function { return caaFunction{} }
+
+type caaFunction struct{}
+
+func (f caaFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "caa"
+}
+
+func (f caaFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Format a CAA record value",
+		Description: "Returns a CAA record in presentation form, e.g. `0 issue \"letsencrypt.org\"`, as exported by `active24_dns_zone_file`. The tag is validated like `caa_tag` of `active24_dns_record`.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{Name: "flags", Description: "CAA flags, 0-255 (128 marks the property critical)"},
+			function.StringParameter{Name: "tag", Description: "CAA property tag, e.g. issue"},
+			function.StringParameter{Name: "value", Description: "Property value"},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f caaFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var flags int64
+	var tag, value string
+	resp.Error = req.Arguments.Get(ctx, &flags, &tag, &value)
+	if resp.Error != nil {
+		return
+	}
+	if flags < 0 || flags > 255 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("CAA flags must be between 0 and 255, got %d.", flags))
+		return
+	}
+	if !containsString(caaTags, strings.ToLower(tag)) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("%q is not a CAA property tag. Valid tags: %s.", tag, strings.Join(caaTags, ", ")))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, zoneRData(zoneRecord{Type: "CAA", CAAFlags: &flags, CAATag: tag, CAAValue: value}))
+}
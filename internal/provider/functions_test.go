@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      `output "caa" { value = provider::active24::caa(0, "issues", "letsencrypt.org") }`,
+				ExpectError: regexp.MustCompile(`"issues" is not a CAA property tag`),
+			},
+			{
+				Config:      `output "zone" { value = provider::active24::parse_zone_file("www A 10.0.0.1\n") }`,
+				ExpectError: regexp.MustCompile(`must start with an \$ORIGIN`),
+			},
+			{
+				Config: `
+output "fqdn" { value = provider::active24::fqdn("www", "example.com") }
+output "fqdn_apex" { value = provider::active24::fqdn("@", "příklad.cz") }
+output "relative" { value = provider::active24::relative_name("WWW.Example.com.", "example.com") }
+output "relative_apex" { value = provider::active24::relative_name("example.com", "example.com") }
+output "split" { value = provider::active24::split_txt("${join("", [for i in range(300) : "a"])}") }
+output "split_quoted" { value = provider::active24::split_txt("\"v=spf1 \" \"-all\"") }
+output "caa" { value = provider::active24::caa(128, "Issue", "ca.example.net; \"x\"") }
+output "zone" {
+  value = provider::active24::parse_zone_file(<<-EOT
+    $ORIGIN example.com.
+    @ 300 IN MX 10 mail
+    _dmarc TXT "v=DMARC1; p=none"
+    EOT
+  )
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("fqdn", knownvalue.StringExact("www.example.com")),
+					statecheck.ExpectKnownOutputValue("fqdn_apex", knownvalue.StringExact("xn--pklad-zsa96e.cz")),
+					statecheck.ExpectKnownOutputValue("relative", knownvalue.StringExact("WWW")),
+					statecheck.ExpectKnownOutputValue("relative_apex", knownvalue.StringExact("@")),
+					statecheck.ExpectKnownOutputValue("split", knownvalue.ListSizeExact(2)),
+					statecheck.ExpectKnownOutputValue("split_quoted", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("v=spf1 "), knownvalue.StringExact("-all"),
+					})),
+					statecheck.ExpectKnownOutputValue("caa", knownvalue.StringExact(`128 issue "ca.example.net; \"x\""`)),
+					statecheck.ExpectKnownOutputValue("zone", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"name": knownvalue.StringExact("@"), "type": knownvalue.StringExact("MX"),
+							"content": knownvalue.StringExact("mail.example.com"), "ttl": knownvalue.Int64Exact(300),
+							"priority": knownvalue.Int64Exact(10),
+						}),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"name": knownvalue.StringExact("_dmarc"), "type": knownvalue.StringExact("TXT"),
+							"content": knownvalue.StringExact("v=DMARC1; p=none"), "ttl": knownvalue.Int64Exact(300),
+							"priority": knownvalue.Null(),
+						}),
+					})),
+				},
+			},
+		},
+	})
+}
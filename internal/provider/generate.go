@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// The generate subcommand brings an existing zone under Terraform: it lists
+// the zone and prints one active24_dns_record resource per record together
+// with a Terraform 1.5 import block, so `terraform plan` imports every record
+// without any manual `terraform import`.
+
+const generateUsage = `Usage: terraform-provider-active24 generate --domain <domain> [--service <id>] [--base-url <url>]
+
+Prints active24_dns_record resources and import blocks for every record of a
+zone. Credentials are read from ACTIVE24_API_KEY and ACTIVE24_API_SECRET.
+
+`
+
+// RunGenerate runs the generate subcommand with the arguments following it
+// and returns the process exit code.
+func RunGenerate(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, generateUsage)
+		fs.PrintDefaults()
+	}
+	domain := fs.String("domain", "", "zone to generate (required)")
+	service := fs.String("service", "", "Active24 service ID, looked up by domain when not set")
+	baseURL := fs.String("base-url", defaultBaseURL, "Active24 API base URL")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if *domain == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	apiKey, apiSecret := getEnv("ACTIVE24_API_KEY"), getEnv("ACTIVE24_API_SECRET")
+	if apiKey == "" || apiSecret == "" {
+		fmt.Fprintln(stderr, "Error: set ACTIVE24_API_KEY and ACTIVE24_API_SECRET.")
+		return 1
+	}
+	client, err := NewClient(*baseURL, apiKey, apiSecret)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	if err := generateZoneConfig(ctx, client, *domain, *service, stdout, stderr); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// generateZoneConfig writes the resources and import blocks for the zone of
+// domain to w. Records the resource cannot manage are reported on warn.
+func generateZoneConfig(ctx context.Context, client DNSAPI, domain, service string, w, warn io.Writer) error {
+	if service == "" {
+		id, err := client.ResolveService(ctx, domain)
+		if err != nil {
+			return err
+		}
+		service = id
+	}
+	records, err := client.ListRecords(ctx, service, "", "", "", nil)
+	if err != nil {
+		return fmt.Errorf("listing records: %w", err)
+	}
+
+	records = append([]DNSRecord(nil), records...)
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := zoneRecordFromAPI(records[i], domain), zoneRecordFromAPI(records[j], domain)
+		if a.Name != b.Name {
+			return zoneNameLess(a.Name, b.Name)
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return zoneRData(a) < zoneRData(b)
+	})
+
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	labels := map[string]int{}
+	for _, rec := range records {
+		if isZoneSystemRecord(rec, domain) {
+			continue
+		}
+		if !containsString(supportedRecordTypes, strings.ToUpper(rec.Type)) {
+			fmt.Fprintf(warn, "Skipping %s record %q: type not supported by active24_dns_record\n", rec.Type, displayName(rec.Name, domain))
+			continue
+		}
+		label := resourceLabel(rec, domain)
+		labels[label]++
+		if n := labels[label]; n > 1 {
+			label += "_" + strconv.Itoa(n)
+		}
+
+		imp := body.AppendNewBlock("import", nil).Body()
+		imp.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: "active24_dns_record"},
+			hcl.TraverseAttr{Name: label},
+		})
+		imp.SetAttributeValue("id", cty.StringVal(fmt.Sprintf("%s:%s:%d", domain, service, rec.ID)))
+		body.AppendNewline()
+
+		res := body.AppendNewBlock("resource", []string{"active24_dns_record", label}).Body()
+		res.SetAttributeValue("domain", cty.StringVal(domain))
+		res.SetAttributeValue("service", cty.StringVal(service))
+		setRecordAttributes(res, rec, domain)
+		body.AppendNewline()
+	}
+	_, err = w.Write(f.Bytes())
+	return err
+}
+
+// setRecordAttributes writes the attributes of rec in the form Read stores
+// after an import, so the first plan after importing is empty.
+func setRecordAttributes(body *hclwrite.Body, rec DNSRecord, domain string) {
+	rtype := strings.ToUpper(rec.Type)
+	body.SetAttributeValue("name", cty.StringVal(displayName(rec.Name, domain)))
+	body.SetAttributeValue("type", cty.StringVal(rtype))
+
+	switch rtype {
+	case "CAA":
+		value := rec.CAAValue
+		if value == "" {
+			value = rec.Content
+		}
+		var flags int64
+		if rec.Flags != nil {
+			flags = *rec.Flags
+		}
+		body.SetAttributeValue("caa_flags", cty.NumberIntVal(flags))
+		body.SetAttributeValue("caa_tag", cty.StringVal(rec.Tag))
+		body.SetAttributeValue("caa_value", cty.StringVal(value))
+	case "SRV":
+		srv := srvData{Target: rec.Content}
+		if rec.Weight != nil && rec.Port != nil {
+			srv.Weight, srv.Port = *rec.Weight, *rec.Port
+		} else if parsed, err := parseSRVContent(rec.Content); err == nil {
+			srv = parsed
+		}
+		// The resource requires a priority for SRV, so fall back to the one
+		// in content or 0 when the API returns none
+		if rec.Priority == nil {
+			rec.Priority = srv.Priority
+		}
+		if rec.Priority == nil {
+			rec.Priority = ptrI(0)
+		}
+		body.SetAttributeValue("srv_weight", cty.NumberIntVal(srv.Weight))
+		body.SetAttributeValue("srv_port", cty.NumberIntVal(srv.Port))
+		body.SetAttributeValue("srv_target", cty.StringVal(srv.Target))
+	case "TXT":
+		body.SetAttributeValue("content", cty.StringVal(txtValue(rec.Content)))
+	case "TLSA", "SSHFP":
+		d, err := parseHexRData(rtype, rec.Content)
+		if err != nil {
+			body.SetAttributeValue("content", cty.StringVal(rec.Content))
+			break
+		}
+		spec := hexRDataSpecs[rtype]
+		for i, name := range spec.Numbers {
+			body.SetAttributeValue(name, cty.NumberIntVal(d.Numbers[i]))
+		}
+		body.SetAttributeValue(spec.Data, cty.StringVal(d.Hex))
+	default:
+		body.SetAttributeValue("content", cty.StringVal(rec.Content))
+	}
+
+	body.SetAttributeValue("ttl", cty.NumberIntVal(rec.TTL))
+	if rec.Priority != nil {
+		body.SetAttributeValue("priority", cty.NumberIntVal(*rec.Priority))
+	}
+}
+
+// resourceLabel derives a Terraform resource name from the record's name
+// and type, e.g. "www_a" or "apex_mx".
+func resourceLabel(rec DNSRecord, domain string) string {
+	name := relativeName(rec.Name, domain)
+	if name == "" {
+		name = "apex"
+	}
+	var b strings.Builder
+	for _, c := range strings.ToLower(name + "_" + rec.Type) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '_' || c == '-' {
+			b.WriteRune(c)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	label := b.String()
+	if label[0] >= '0' && label[0] <= '9' || label[0] == '-' {
+		label = "r_" + label
+	}
+	return label
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"github.com/JoystiC/terraform-provider-active24/internal/fakeapi"
+)
+
+func testAccGenerateZone(srv *fakeapi.Server) {
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "SOA", Content: "ns1.active24.cz. hostmaster.active24.cz. 1 3600 900 604800 3600", TTL: 3600})
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "NS", Content: "ns1.active24.cz", TTL: 3600})
+	srv.Put(testAccService, fakeapi.Record{Name: "www", Type: "A", Content: "10.0.0.1", TTL: 300})
+	srv.Put(testAccService, fakeapi.Record{Name: "www", Type: "A", Content: "10.0.0.2", TTL: 300})
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "MX", Content: "mail.example.com", TTL: 3600, Priority: ptrI(10)})
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "TXT", Content: "v=spf1 ${not_interpolated} -all", TTL: 3600})
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "CAA", CAAValue: "letsencrypt.org", Flags: ptrI(0), Tag: "issue", TTL: 3600})
+	srv.Put(testAccService, fakeapi.Record{Name: "_sip._tcp", Type: "SRV", Content: "sip.example.com", TTL: 3600, Priority: ptrI(10), Weight: ptrI(5), Port: ptrI(5060)})
+}
+
+func TestGenerateZoneConfig(t *testing.T) {
+	srv := fakeapi.New("key", "secret")
+	t.Cleanup(srv.Close)
+	srv.AddService(testAccService, testAccDomain)
+	testAccGenerateZone(srv)
+
+	var out, warn bytes.Buffer
+	if err := generateZoneConfig(context.Background(), newTestClient(t, srv), testAccDomain, "", &out, &warn); err != nil {
+		t.Fatal(err)
+	}
+	want := `import {
+  to = active24_dns_record.apex_caa
+  id = "example.com:12345678:300000007"
+}
+
+resource "active24_dns_record" "apex_caa" {
+  domain    = "example.com"
+  service   = "12345678"
+  name      = "@"
+  type      = "CAA"
+  caa_flags = 0
+  caa_tag   = "issue"
+  caa_value = "letsencrypt.org"
+  ttl       = 3600
+}
+
+import {
+  to = active24_dns_record.apex_mx
+  id = "example.com:12345678:300000005"
+}
+
+resource "active24_dns_record" "apex_mx" {
+  domain   = "example.com"
+  service  = "12345678"
+  name     = "@"
+  type     = "MX"
+  content  = "mail.example.com"
+  ttl      = 3600
+  priority = 10
+}
+
+import {
+  to = active24_dns_record.apex_txt
+  id = "example.com:12345678:300000006"
+}
+
+resource "active24_dns_record" "apex_txt" {
+  domain  = "example.com"
+  service = "12345678"
+  name    = "@"
+  type    = "TXT"
+  content = "v=spf1 $${not_interpolated} -all"
+  ttl     = 3600
+}
+
+import {
+  to = active24_dns_record._sip__tcp_srv
+  id = "example.com:12345678:300000008"
+}
+
+resource "active24_dns_record" "_sip__tcp_srv" {
+  domain     = "example.com"
+  service    = "12345678"
+  name       = "_sip._tcp"
+  type       = "SRV"
+  srv_weight = 5
+  srv_port   = 5060
+  srv_target = "sip.example.com"
+  ttl        = 3600
+  priority   = 10
+}
+
+import {
+  to = active24_dns_record.www_a
+  id = "example.com:12345678:300000003"
+}
+
+resource "active24_dns_record" "www_a" {
+  domain  = "example.com"
+  service = "12345678"
+  name    = "www"
+  type    = "A"
+  content = "10.0.0.1"
+  ttl     = 300
+}
+
+import {
+  to = active24_dns_record.www_a_2
+  id = "example.com:12345678:300000004"
+}
+
+resource "active24_dns_record" "www_a_2" {
+  domain  = "example.com"
+  service = "12345678"
+  name    = "www"
+  type    = "A"
+  content = "10.0.0.2"
+  ttl     = 300
+}
+
+`
+	if got := out.String(); got != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+	if warn.Len() != 0 {
+		t.Errorf("unexpected warnings: %s", warn.String())
+	}
+}
+
+func TestAccGenerate_importsWithoutChanges(t *testing.T) {
+	srv := newTestAccServer(t)
+	testAccGenerateZone(srv)
+
+	var out bytes.Buffer
+	if err := generateZoneConfig(context.Background(), newTestClient(t, srv), testAccDomain, testAccService, &out, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + out.String(),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("active24_dns_record.www_a_2", plancheck.ResourceActionNoop),
+						plancheck.ExpectResourceAction("active24_dns_record._sip__tcp_srv", plancheck.ResourceActionNoop),
+						plancheck.ExpectResourceAction("active24_dns_record.apex_caa", plancheck.ResourceActionNoop),
+						plancheck.ExpectResourceAction("active24_dns_record.apex_txt", plancheck.ResourceActionNoop),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestRunGenerateUsage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := RunGenerate(context.Background(), []string{"--service", "1"}, &stdout, &stderr); code != 2 {
+		t.Errorf("exit code = %d, want 2", code)
+	}
+	if !strings.HasPrefix(stderr.String(), "Usage: terraform-provider-active24 generate") {
+		t.Errorf("unexpected usage output: %s", stderr.String())
+	}
+}
+
+func TestGenerateSRVPriority(t *testing.T) {
+	tests := []struct {
+		name string
+		rec  DNSRecord
+		want string
+	}{
+		{
+			name: "priority field",
+			rec:  DNSRecord{Content: "sip.example.com", Priority: ptrI(10), Weight: ptrI(5), Port: ptrI(5060)},
+			want: "priority   = 10\n",
+		},
+		{
+			name: "priority in content",
+			rec:  DNSRecord{Content: "20 5 5060 sip.example.com"},
+			want: "priority   = 20\n",
+		},
+		{
+			name: "no priority",
+			rec:  DNSRecord{Content: "sip.example.com", Weight: ptrI(5), Port: ptrI(5060)},
+			want: "priority   = 0\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rec.Name, tt.rec.Type, tt.rec.TTL = "_sip._tcp", "SRV", 3600
+			f := hclwrite.NewEmptyFile()
+			setRecordAttributes(f.Body(), tt.rec, testAccDomain)
+			if got := string(f.Bytes()); !strings.Contains(got, tt.want) {
+				t.Errorf("output does not contain %q:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAccGenerate_srvWithoutPriority(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.Put(testAccService, fakeapi.Record{Name: "_sip._tcp", Type: "SRV", Content: "sip.example.com", TTL: 3600, Weight: ptrI(5), Port: ptrI(5060)})
+
+	var out bytes.Buffer
+	if err := generateZoneConfig(context.Background(), newTestClient(t, srv), testAccDomain, testAccService, &out, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The generated config is valid; the missing priority is set to 0
+				Config: testAccProviderConfig(srv) + out.String(),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("active24_dns_record._sip__tcp_srv", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("active24_dns_record._sip__tcp_srv", "priority", "0"),
+			},
+		},
+	})
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// Internationalized domain names (e.g. příklad.cz) are sent to the API as
+// IDNA A-labels (xn--pklad-zsa96e.cz) and compared in that form, so the
+// Unicode and punycode spellings of a name are interchangeable.
+
+// idnaProfile converts labels without the strict hostname rules of
+// idna.Lookup, which would reject service labels such as _dmarc or _sip.
+var idnaProfile = idna.New(idna.MapForLookup(), idna.Transitional(false), idna.StrictDomainName(false))
+
+// toASCII converts every non-ASCII label of name to its A-label. ASCII labels
+// are returned unchanged, so names that never contained Unicode keep their
+// spelling. Labels that cannot be converted are left as they are.
+func toASCII(name string) string {
+	if isASCII(name) {
+		return name
+	}
+	labels := strings.Split(name, ".")
+	for i, l := range labels {
+		if isASCII(l) {
+			continue
+		}
+		if a, err := idnaProfile.ToASCII(l); err == nil {
+			labels[i] = a
+		}
+	}
+	return strings.Join(labels, ".")
+}
+
+// toUnicode converts every A-label (xn--) of name to Unicode.
+func toUnicode(name string) string {
+	labels := strings.Split(name, ".")
+	for i, l := range labels {
+		if !strings.HasPrefix(strings.ToLower(l), "xn--") {
+			continue
+		}
+		if u, err := idnaProfile.ToUnicode(l); err == nil {
+			labels[i] = u
+		}
+	}
+	return strings.Join(labels, ".")
+}
+
+// unicodeName returns name relative to domain in Unicode form, "@" for the
+// zone apex.
+func unicodeName(name, domain string) string {
+	return toUnicode(displayName(name, domain))
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// hostContentTypes are record types whose content is a host name.
+var hostContentTypes = []string{"CNAME", "MX", "NS", "PTR", "SRV"}
+
+// contentForAPI converts a host name content to A-labels. SRV content is
+// expected to be the bare target at this point.
+func contentForAPI(rtype, content string) string {
+	if containsString(hostContentTypes, strings.ToUpper(rtype)) {
+		return toASCII(content)
+	}
+	return content
+}
@@ -0,0 +1,74 @@
+package provider
+
+import "strings"
+
+// Record names can be written relative to the zone ("www"), as "@" for the
+// apex, or fully qualified with or without the trailing dot
+// ("www.example.com", "www.example.com."). The API expects names relative to
+// the zone in A-label form with "" for the apex, and Terraform state uses the
+// same form with "@" for the apex. Every conversion between these spellings
+// goes through the functions in this file.
+
+// relativeName converts a record name in any accepted spelling into the
+// relative form used by the API ("" for the apex). The zone suffix is matched
+// case-insensitively and in A-label form, so Unicode and punycode spellings
+// of the same name are equal.
+func relativeName(name, domain string) string {
+	name = toASCII(strings.TrimSpace(name))
+	domain = strings.TrimSuffix(toASCII(strings.TrimSpace(domain)), ".")
+	if name == "@" || name == "" {
+		return ""
+	}
+	name = strings.TrimSuffix(name, ".")
+	lower, zone := strings.ToLower(name), strings.ToLower(domain)
+	switch {
+	case zone == "":
+		return name
+	case lower == zone:
+		return ""
+	case strings.HasSuffix(lower, "."+zone):
+		// e.g. "devtest.dev.finbricks.com" -> "devtest.dev"
+		return name[:len(name)-len(zone)-1]
+	}
+	return name
+}
+
+// displayName converts a record name returned by the API into the form used
+// in Terraform state: relative to the zone, "@" for the apex.
+func displayName(apiName, domain string) string {
+	return denormalizeNameFromAPI(relativeName(apiName, domain))
+}
+
+// denormalizeNameFromAPI converts API apex representation back to Terraform-friendly "@".
+func denormalizeNameFromAPI(name string) string {
+	if name == "" {
+		return "@"
+	}
+	return name
+}
+
+// sameName reports whether a and b name the same record in domain.
+func sameName(a, b, domain string) bool {
+	return strings.EqualFold(relativeName(a, domain), relativeName(b, domain))
+}
+
+// fqdn returns the fully qualified name of a record in A-label form, without
+// the trailing dot.
+func fqdn(name, domain string) string {
+	zone := strings.TrimSuffix(toASCII(strings.TrimSpace(domain)), ".")
+	if rel := relativeName(name, domain); rel != "" {
+		return rel + "." + zone
+	}
+	return zone
+}
+
+// nameIncludesZone reports whether name is written with the zone suffix but
+// without the trailing dot that marks it as fully qualified. Such a name is
+// treated as an FQDN, although in a zone file it would be relative.
+func nameIncludesZone(name, domain string) bool {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "@" || strings.HasSuffix(name, ".") {
+		return false
+	}
+	return !strings.EqualFold(toASCII(name), relativeName(name, domain))
+}
@@ -0,0 +1,31 @@
+package provider
+
+import "testing"
+
+func TestRelativeName(t *testing.T) {
+	cases := []struct {
+		name, relative, fqdn string
+		includesZone         bool
+	}{
+		{"www", "www", "www.example.com", false},
+		{"@", "", "example.com", false},
+		{"www.example.com", "www", "www.example.com", true},
+		{"www.example.com.", "www", "www.example.com", false},
+		{"WWW.Example.COM.", "WWW", "WWW.example.com", false},
+		{"example.com", "", "example.com", true},
+		{"example.com.", "", "example.com", false},
+		{"www.example.community", "www.example.community", "www.example.community.example.com", false},
+		{"dev.finbricks.com.example.com", "dev.finbricks.com", "dev.finbricks.com.example.com", true},
+	}
+	for _, c := range cases {
+		if got := relativeName(c.name, "example.com"); got != c.relative {
+			t.Errorf("relativeName(%q) = %q, want %q", c.name, got, c.relative)
+		}
+		if got := fqdn(c.name, "example.com"); got != c.fqdn {
+			t.Errorf("fqdn(%q) = %q, want %q", c.name, got, c.fqdn)
+		}
+		if got := nameIncludesZone(c.name, "example.com"); got != c.includesZone {
+			t.Errorf("nameIncludesZone(%q) = %v, want %v", c.name, got, c.includesZone)
+		}
+	}
+}
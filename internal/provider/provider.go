@@ -3,8 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -17,6 +20,7 @@ type Active24Provider struct {
 
 // Ensure provider implementation
 var _ provider.Provider = &Active24Provider{}
+var _ provider.ProviderWithFunctions = &Active24Provider{}
 
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
@@ -32,6 +36,15 @@ type providerModel struct {
 	Password types.String `tfsdk:"password"`
 	APIToken types.String `tfsdk:"api_token"`
 	BaseURL  types.String `tfsdk:"base_url"`
+	// Retry policy
+	MaxRetries   types.Int64 `tfsdk:"max_retries"`
+	RetryWaitMin types.Int64 `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.Int64 `tfsdk:"retry_wait_max"`
+	// Client-side rate limit
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+	// Per-request HTTP timeout
+	RequestTimeout types.Int64 `tfsdk:"request_timeout"`
 }
 
 func (p *Active24Provider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -60,6 +73,30 @@ func (p *Active24Provider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Optional:    true,
 				Description: "Base URL for Active24 API.",
 			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of retries for rate-limited (429) and transient (5xx, network) failures. Defaults to 3. Set to 0 to disable retries.",
+			},
+			"retry_wait_min": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Minimum backoff between retries, in seconds. Defaults to 1.",
+			},
+			"retry_wait_max": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum backoff between retries, in seconds. Defaults to 30. A `Retry-After` longer than this fails the request instead of waiting.",
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum sustained request rate shared by all resources of this provider. Defaults to 5. Set to 0 to disable client-side throttling (rate-limit headers from the API are still honoured).",
+			},
+			"burst": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of requests that may be sent at once before `requests_per_second` applies. Defaults to 10.",
+			},
+			"request_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time limit of a single API request, in seconds. Defaults to 15. Set to 0 to rely on the resource `timeouts` only.",
+			},
 		},
 	}
 }
@@ -105,26 +142,97 @@ func (p *Active24Provider) Configure(ctx context.Context, req provider.Configure
 
 	baseURL := config.BaseURL.ValueString()
 	if baseURL == "" {
-		// Default to v2 REST API base per docs
-		baseURL = "https://rest.active24.cz/v2"
+		baseURL = defaultBaseURL
 	}
 
-	c, err := NewClient(baseURL, apiKey, apiSecret)
+	maxRetries := defaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	retryWaitMin := defaultRetryWaitMin
+	if !config.RetryWaitMin.IsNull() {
+		retryWaitMin = time.Duration(config.RetryWaitMin.ValueInt64()) * time.Second
+	}
+	retryWaitMax := defaultRetryWaitMax
+	if !config.RetryWaitMax.IsNull() {
+		retryWaitMax = time.Duration(config.RetryWaitMax.ValueInt64()) * time.Second
+	}
+	if maxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid max_retries", "`max_retries` must not be negative.")
+		return
+	}
+	if retryWaitMin < 0 || retryWaitMax < retryWaitMin {
+		resp.Diagnostics.AddAttributeError(path.Root("retry_wait_max"), "Invalid retry wait",
+			"`retry_wait_min` must not be negative and `retry_wait_max` must be greater than or equal to `retry_wait_min`.")
+		return
+	}
+
+	rps := float64(defaultRequestsPerSecond)
+	if !config.RequestsPerSecond.IsNull() {
+		rps = config.RequestsPerSecond.ValueFloat64()
+	}
+	burst := defaultBurst
+	if !config.Burst.IsNull() {
+		burst = int(config.Burst.ValueInt64())
+	}
+	if rps < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("requests_per_second"), "Invalid requests_per_second", "`requests_per_second` must not be negative.")
+		return
+	}
+	if burst < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("burst"), "Invalid burst", "`burst` must be at least 1.")
+		return
+	}
+
+	requestTimeout := defaultRequestTimeout
+	if !config.RequestTimeout.IsNull() {
+		requestTimeout = time.Duration(config.RequestTimeout.ValueInt64()) * time.Second
+	}
+	if requestTimeout < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("request_timeout"), "Invalid request_timeout", "`request_timeout` must not be negative.")
+		return
+	}
+
+	c, err := NewClient(baseURL, apiKey, apiSecret,
+		WithRetry(maxRetries, retryWaitMin, retryWaitMax),
+		WithRateLimit(rps, burst),
+		WithRequestTimeout(requestTimeout),
+	)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create client", fmt.Sprintf("error: %v", err))
 		return
 	}
 
-	resp.DataSourceData = c
-	resp.ResourceData = c
+	// Resources and data sources only depend on the DNSAPI interface
+	var api DNSAPI = c
+	resp.DataSourceData = api
+	resp.ResourceData = api
 }
 
 func (p *Active24Provider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewDNSRecordResource,
+		NewDNSZoneRecordsResource,
+		NewDNSRecordSetResource,
+		NewDNSZoneFileRecordsResource,
 	}
 }
 
 func (p *Active24Provider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewDNSRecordDataSource,
+		NewDNSRecordsDataSource,
+		NewServicesDataSource,
+		NewDNSZoneFileDataSource,
+	}
+}
+
+func (p *Active24Provider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewFQDNFunction,
+		NewRelativeNameFunction,
+		NewParseZoneFileFunction,
+		NewSplitTXTFunction,
+		NewCAAFunction,
+	}
 }
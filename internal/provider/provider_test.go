@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/JoystiC/terraform-provider-active24/internal/fakeapi"
+)
+
+// testAccProtoV6ProviderFactories serves the provider in-process for acceptance tests.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"active24": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+const (
+	testAccService = "12345678"
+	testAccDomain  = "example.com"
+)
+
+// newTestAccServer starts a fake Active24 API with one DNS service for
+// testAccService/testAccDomain. It is shut down when the test ends.
+func newTestAccServer(t *testing.T) *fakeapi.Server {
+	t.Helper()
+	srv := fakeapi.New("acc-key", "acc-secret")
+	t.Cleanup(srv.Close)
+	srv.AddService(testAccService, testAccDomain)
+	return srv
+}
+
+// testAccProviderConfig returns a provider block pointing at srv.
+func testAccProviderConfig(srv *fakeapi.Server) string {
+	return fmt.Sprintf(`
+provider "active24" {
+  api_key             = %q
+  api_secret          = %q
+  base_url            = %q
+  requests_per_second = 0
+  retry_wait_min      = 0
+  retry_wait_max      = 0
+}
+`, srv.APIKey, srv.APISecret, srv.BaseURL())
+}
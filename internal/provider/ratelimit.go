@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by every request of a Client. Besides
+// the configured rate it honours the API's own rate-limit headers: when the
+// server reports few remaining requests the rate is lowered until the window
+// resets, and a 429 pauses all callers until Retry-After has passed.
+type rateLimiter struct {
+	mu sync.Mutex
+
+	rate  float64 // configured tokens per second
+	burst float64
+
+	tokens      float64
+	last        time.Time
+	adaptedRate float64   // rate derived from API headers, 0 when not adapted
+	adaptedTill time.Time // when the adapted rate expires
+	pausedTill  time.Time
+	// maxPause caps the pause requested by Retry-After, negative means no cap
+	maxPause time.Duration
+
+	now func() time.Time
+}
+
+// newRateLimiter returns a limiter allowing rps requests per second with the
+// given burst. A non-positive rps disables the configured limit, while API
+// headers are still honoured.
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:     rps,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		maxPause: -1,
+		now:      time.Now,
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	for {
+		d := l.reserve()
+		if d <= 0 {
+			return nil
+		}
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns 0, otherwise it
+// returns how long to wait before trying again.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Before(l.pausedTill) {
+		return l.pausedTill.Sub(now)
+	}
+
+	rate := l.currentRate(now)
+	if rate <= 0 {
+		return 0
+	}
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / rate * float64(time.Second))
+}
+
+func (l *rateLimiter) currentRate(now time.Time) float64 {
+	if l.adaptedRate > 0 && now.Before(l.adaptedTill) {
+		if l.rate <= 0 || l.adaptedRate < l.rate {
+			return l.adaptedRate
+		}
+	}
+	return l.rate
+}
+
+// Observe adjusts the limiter from a response. It understands Retry-After on
+// 429 responses and the X-RateLimit-Remaining / X-RateLimit-Reset pair.
+func (l *rateLimiter) Observe(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		d := parseRetryAfter(resp.Header.Get("Retry-After"), now)
+		if l.maxPause >= 0 && d > l.maxPause {
+			// The client fails such a request instead of waiting, so other
+			// requests are not held back either
+			d = l.maxPause
+		}
+		if d > 0 {
+			l.pauseUntil(now.Add(d))
+		}
+	}
+
+	remaining, err := strconv.Atoi(firstHeader(resp.Header, "X-RateLimit-Remaining", "RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset := parseRateLimitReset(firstHeader(resp.Header, "X-RateLimit-Reset", "RateLimit-Reset"), now)
+	if reset <= 0 {
+		return
+	}
+	if remaining <= 0 {
+		l.pauseUntil(now.Add(reset))
+		return
+	}
+	// Spread the remaining budget evenly over the rest of the window
+	l.adaptedRate = float64(remaining) / reset.Seconds()
+	l.adaptedTill = now.Add(reset)
+}
+
+func (l *rateLimiter) pauseUntil(t time.Time) {
+	if t.After(l.pausedTill) {
+		l.pausedTill = t
+	}
+	l.tokens = 0
+	l.last = t
+}
+
+func firstHeader(h http.Header, keys ...string) string {
+	for _, k := range keys {
+		if v := h.Get(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseRateLimitReset accepts either seconds until reset or a Unix timestamp.
+func parseRateLimitReset(v string, now time.Time) time.Duration {
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 {
+		return 0
+	}
+	// Values that look like a Unix timestamp are absolute
+	if n > 1_000_000_000 {
+		return time.Unix(n, 0).Sub(now)
+	}
+	return time.Duration(n) * time.Second
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var testLimiterEpoch = time.Unix(1_700_000_000, 0)
+
+// limiterStep advances the fake clock, then either observes a response with
+// the given status and headers or, when status is 0, reserves a token and
+// expects the returned wait.
+type limiterStep struct {
+	advance time.Duration
+	status  int
+	header  map[string]string
+	wait    time.Duration
+}
+
+func TestRateLimiter(t *testing.T) {
+	take := limiterStep{}
+	waitFor := func(d time.Duration) limiterStep { return limiterStep{wait: d} }
+	after := func(d time.Duration, wait time.Duration) limiterStep { return limiterStep{advance: d, wait: wait} }
+	observe := func(status int, header map[string]string) limiterStep {
+		return limiterStep{status: status, header: header}
+	}
+
+	tests := []struct {
+		name     string
+		rps      float64
+		burst    int
+		maxPause time.Duration
+		steps    []limiterStep
+	}{
+		{
+			name: "burst is consumed at once", rps: 2, burst: 3,
+			steps: []limiterStep{take, take, take, waitFor(500 * time.Millisecond)},
+		},
+		{
+			name: "tokens refill at the configured rate", rps: 2, burst: 1,
+			steps: []limiterStep{
+				take,
+				after(250*time.Millisecond, 250*time.Millisecond),
+				after(250*time.Millisecond, 0),
+				waitFor(500 * time.Millisecond),
+			},
+		},
+		{
+			name: "refill is capped at burst", rps: 1, burst: 2,
+			steps: []limiterStep{take, take, after(10*time.Second, 0), take, waitFor(time.Second)},
+		},
+		{
+			name: "no remaining requests pause until reset", rps: 10, burst: 5,
+			steps: []limiterStep{
+				observe(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "5"}),
+				waitFor(5 * time.Second),
+				after(4*time.Second, time.Second),
+				// The bucket is empty after a pause
+				after(time.Second, 100*time.Millisecond),
+			},
+		},
+		{
+			name: "reset as unix timestamp", rps: 10, burst: 1,
+			steps: []limiterStep{
+				observe(http.StatusOK, map[string]string{
+					"RateLimit-Remaining": "0",
+					"RateLimit-Reset":     strconv.FormatInt(testLimiterEpoch.Unix()+4, 10),
+				}),
+				waitFor(4 * time.Second),
+			},
+		},
+		{
+			name: "remaining budget is spread over the window", rps: 10, burst: 1,
+			steps: []limiterStep{
+				observe(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "2", "X-RateLimit-Reset": "10"}),
+				take,
+				waitFor(5 * time.Second),
+				// The configured rate applies again once the window has reset
+				after(10*time.Second, 0),
+				waitFor(100 * time.Millisecond),
+			},
+		},
+		{
+			name: "headers never raise the configured rate", rps: 1, burst: 1,
+			steps: []limiterStep{
+				observe(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "100", "X-RateLimit-Reset": "1"}),
+				take,
+				waitFor(time.Second),
+			},
+		},
+		{
+			name: "unparsable reset is ignored", rps: 10, burst: 1,
+			steps: []limiterStep{
+				observe(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "soon"}),
+				take,
+			},
+		},
+		{
+			name: "retry-after pauses on 429", rps: 10, burst: 5,
+			steps: []limiterStep{
+				observe(http.StatusTooManyRequests, map[string]string{"Retry-After": "3"}),
+				waitFor(3 * time.Second),
+			},
+		},
+		{
+			name: "retry-after is capped at maxPause", rps: 10, burst: 5, maxPause: 30 * time.Second,
+			steps: []limiterStep{
+				observe(http.StatusTooManyRequests, map[string]string{"Retry-After": "86400"}),
+				waitFor(30 * time.Second),
+			},
+		},
+		{
+			name: "retry-after as http date", rps: 10, burst: 5,
+			steps: []limiterStep{
+				observe(http.StatusTooManyRequests, map[string]string{
+					"Retry-After": testLimiterEpoch.Add(2 * time.Second).UTC().Format(http.TimeFormat),
+				}),
+				waitFor(2 * time.Second),
+			},
+		},
+		{
+			name: "retry-after is ignored without 429", rps: 10, burst: 5,
+			steps: []limiterStep{
+				observe(http.StatusOK, map[string]string{"Retry-After": "3"}),
+				take,
+			},
+		},
+		{
+			name: "disabled limit does not throttle", rps: 0, burst: 1,
+			steps: []limiterStep{take, take, take},
+		},
+		{
+			name: "disabled limit honours retry-after", rps: 0, burst: 1,
+			steps: []limiterStep{
+				observe(http.StatusTooManyRequests, map[string]string{"Retry-After": "3"}),
+				waitFor(3 * time.Second),
+				after(3*time.Second, 0),
+				take,
+			},
+		},
+		{
+			name: "disabled limit honours remaining budget", rps: 0, burst: 1,
+			steps: []limiterStep{
+				observe(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "1", "X-RateLimit-Reset": "2"}),
+				take,
+				waitFor(2 * time.Second),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := testLimiterEpoch
+			l := newRateLimiter(tt.rps, tt.burst)
+			if tt.maxPause != 0 {
+				l.maxPause = tt.maxPause
+			}
+			l.now = func() time.Time { return now }
+			for i, step := range tt.steps {
+				now = now.Add(step.advance)
+				if step.status != 0 {
+					resp := &http.Response{StatusCode: step.status, Header: http.Header{}}
+					for k, v := range step.header {
+						resp.Header.Set(k, v)
+					}
+					l.Observe(resp)
+					continue
+				}
+				if got := l.reserve(); got != step.wait {
+					t.Fatalf("step %d: reserve() = %s, want %s", i, got, step.wait)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	l := newRateLimiter(1, 1)
+	l.Observe(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"60"}}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait returned after %s, want right after the context ended", elapsed)
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"30", 30 * time.Second},
+		{strconv.FormatInt(testLimiterEpoch.Unix()+90, 10), 90 * time.Second},
+		{"0", 0},
+		{"-5", 0},
+		{"", 0},
+		{"1.5", 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRateLimitReset(tt.in, testLimiterEpoch); got != tt.want {
+			t.Errorf("parseRateLimitReset(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TLSA (RFC 6698) and SSHFP (RFC 4255) records have no dedicated fields in
+// the Active24 API. Their content is the zone-file presentation form: a few
+// small numbers followed by hex data, e.g. "3 1 1 0a1b2c...".
+
+// hexRData is the RDATA of a TLSA or SSHFP record.
+type hexRData struct {
+	Numbers []int64
+	Hex     string
+}
+
+// hexRDataSpec describes the presentation form of one record type.
+type hexRDataSpec struct {
+	// Numbers are the attribute names of the leading numeric fields and
+	// Allowed the values accepted for each of them.
+	Numbers []string
+	Allowed [][]int64
+	// Data is the attribute name of the hex data.
+	Data string
+	// DigestLength returns the expected number of hex digits, or 0 when any
+	// length is allowed.
+	DigestLength func(numbers []int64) int
+}
+
+var hexRDataSpecs = map[string]hexRDataSpec{
+	"TLSA": {
+		Numbers: []string{"tlsa_usage", "tlsa_selector", "tlsa_matching_type"},
+		Allowed: [][]int64{{0, 1, 2, 3}, {0, 1}, {0, 1, 2}},
+		Data:    "tlsa_cert_data",
+		DigestLength: func(numbers []int64) int {
+			switch numbers[2] {
+			case 1: // SHA-256
+				return 64
+			case 2: // SHA-512
+				return 128
+			}
+			return 0
+		},
+	},
+	"SSHFP": {
+		Numbers: []string{"sshfp_algorithm", "sshfp_fingerprint_type"},
+		Allowed: [][]int64{{1, 2, 3, 4, 6}, {1, 2}},
+		Data:    "sshfp_fingerprint",
+		DigestLength: func(numbers []int64) int {
+			switch numbers[1] {
+			case 1: // SHA-1
+				return 40
+			case 2: // SHA-256
+				return 64
+			}
+			return 0
+		},
+	},
+}
+
+// parseHexRData parses the presentation form of a TLSA or SSHFP record. The
+// hex data may be split by whitespace, as zone files allow.
+func parseHexRData(rtype, s string) (hexRData, error) {
+	spec := hexRDataSpecs[strings.ToUpper(rtype)]
+	n := len(spec.Numbers)
+	fields := strings.Fields(s)
+	if len(fields) < n+1 {
+		return hexRData{}, fmt.Errorf("%s content %q must have %d numbers followed by hex data", strings.ToUpper(rtype), s, n)
+	}
+	out := hexRData{Numbers: make([]int64, n)}
+	for i, f := range fields[:n] {
+		v, err := strconv.ParseUint(f, 10, 8)
+		if err != nil {
+			return hexRData{}, fmt.Errorf("%s content %q: %q is not a number between 0 and 255", strings.ToUpper(rtype), s, f)
+		}
+		out.Numbers[i] = int64(v)
+	}
+	out.Hex = strings.Join(fields[n:], "")
+	return out, nil
+}
+
+// String renders d in presentation form.
+func (d hexRData) String() string {
+	parts := make([]string, 0, len(d.Numbers)+1)
+	for _, v := range d.Numbers {
+		parts = append(parts, strconv.FormatInt(v, 10))
+	}
+	return strings.Join(append(parts, d.Hex), " ")
+}
+
+// equal compares two records, ignoring the case of the hex data.
+func (d hexRData) equal(o hexRData) bool {
+	if len(d.Numbers) != len(o.Numbers) {
+		return false
+	}
+	for i := range d.Numbers {
+		if d.Numbers[i] != o.Numbers[i] {
+			return false
+		}
+	}
+	return strings.EqualFold(d.Hex, o.Hex)
+}
+
+// check validates d against the spec. It returns the index of the offending
+// field (len(Numbers) for the hex data) together with the error.
+func (spec hexRDataSpec) check(d hexRData) (int, error) {
+	for i, v := range d.Numbers {
+		if !containsInt64(spec.Allowed[i], v) {
+			return i, fmt.Errorf("%s must be one of %s, got %d", spec.Numbers[i], formatInt64s(spec.Allowed[i]), v)
+		}
+	}
+	data := len(spec.Numbers)
+	if _, err := hex.DecodeString(d.Hex); err != nil || d.Hex == "" {
+		return data, fmt.Errorf("%s must be an even number of hexadecimal digits", spec.Data)
+	}
+	if want := spec.DigestLength(d.Numbers); want != 0 && len(d.Hex) != want {
+		return data, fmt.Errorf("%s must be %d hexadecimal digits for this digest type, got %d", spec.Data, want, len(d.Hex))
+	}
+	return -1, nil
+}
+
+func containsInt64(list []int64, v int64) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func formatInt64s(list []int64) string {
+	parts := make([]string, len(list))
+	for i, v := range list {
+		parts[i] = strconv.FormatInt(v, 10)
+	}
+	return strings.Join(parts, ", ")
+}
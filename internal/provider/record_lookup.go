@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// recordLookupError is returned by findRecordByNameType when the lookup does
+// not resolve to exactly one record.
+type recordLookupError struct {
+	Domain  string
+	Service string
+	Name    string
+	Type    string
+	Content string
+	// Matches holds the records matching name+type. It is empty when nothing
+	// matched and has several entries when content did not narrow them down.
+	Matches []DNSRecord
+}
+
+func (e *recordLookupError) Error() string {
+	switch {
+	case len(e.Matches) == 0:
+		return fmt.Sprintf("No %s record named '%s' found in zone %s (service %s)", e.Type, e.Name, e.Domain, e.Service)
+	case e.Content != "":
+		return fmt.Sprintf("Found %d %s records named '%s' but none matching content '%s'", len(e.Matches), e.Type, e.Name, e.Content)
+	default:
+		return fmt.Sprintf("Found %d %s records named '%s'", len(e.Matches), e.Type, e.Name)
+	}
+}
+
+// ambiguous reports whether several records matched and no content filter was given.
+func (e *recordLookupError) ambiguous() bool {
+	return len(e.Matches) > 1 && e.Content == ""
+}
+
+// matchList formats the matching records one per line for diagnostics.
+func (e *recordLookupError) matchList() string {
+	var ids []string
+	for _, m := range e.Matches {
+		detail := recordContent(m)
+		if m.CAAValue != "" {
+			detail = fmt.Sprintf("%s (tag=%s)", m.CAAValue, m.Tag)
+		}
+		ids = append(ids, fmt.Sprintf("  ID %d: %s", m.ID, detail))
+	}
+	return strings.Join(ids, "\n")
+}
+
+// findRecordByNameType looks up the single record with the given relative
+// name ("" for apex) and type. Active24 filters names by substring, so the
+// exact name match is done client-side. A non-empty content must match the
+// record as well and selects one of them when several share name and type.
+func findRecordByNameType(ctx context.Context, client DNSAPI, domain, service, name, rtype, content string) (*DNSRecord, error) {
+	records, err := client.ListRecords(ctx, service, name, strings.ToUpper(rtype), "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find matching records by name and type
+	var matches []DNSRecord
+	for i := range records {
+		if sameName(records[i].Name, name, domain) && strings.EqualFold(records[i].Type, rtype) {
+			matches = append(matches, records[i])
+		}
+	}
+
+	lookupErr := &recordLookupError{Domain: domain, Service: service, Name: name, Type: rtype, Content: content, Matches: matches}
+	if content == "" {
+		if len(matches) != 1 {
+			return nil, lookupErr
+		}
+		return &matches[0], nil
+	}
+	for i := range matches {
+		if recordContentMatches(matches[i], content) {
+			return &matches[i], nil
+		}
+	}
+	return nil, lookupErr
+}
+
+// recordContentMatches reports whether content selects rec. It is compared
+// against the normalized content, the raw content and the CAA value.
+func recordContentMatches(rec DNSRecord, content string) bool {
+	return contentEqual(rec.Type, recordContent(rec), content) ||
+		strings.EqualFold(rec.Content, content) ||
+		contentEqual("CAA", rec.CAAValue, content)
+}
@@ -0,0 +1,327 @@
+package provider
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Active24 accepts TTLs between one minute and one week.
+const (
+	minRecordTTL = 60
+	maxRecordTTL = 604800
+)
+
+// supportedRecordTypes are the types active24_dns_record can manage. SOA is
+// owned by Active24 and cannot be created.
+var supportedRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "TXT", "SRV", "CAA", "NS", "PTR", "TLSA", "SSHFP"}
+
+// caaTags are the property tags registered for CAA (RFC 8659, RFC 9495).
+var caaTags = []string{"issue", "issuewild", "iodef", "contactemail", "contactphone", "issuemail", "issuevmc"}
+
+// validateRecordConfig runs the checks shared by every record type of an
+// active24_dns_record configuration.
+func validateRecordConfig(config *dnsRecordModel, diags *diag.Diagnostics) {
+	rtype := strings.ToUpper(config.Type.ValueString())
+	if !containsString(supportedRecordTypes, rtype) {
+		diags.AddAttributeError(path.Root("type"), "Unsupported record type",
+			fmt.Sprintf("Record type %q is not supported. Supported types: %s.", config.Type.ValueString(), strings.Join(supportedRecordTypes, ", ")))
+		return
+	}
+
+	validateNameConfig(config.Name, config.Domain, diags)
+
+	if !config.TTL.IsNull() && !config.TTL.IsUnknown() {
+		if ttl := config.TTL.ValueInt64(); ttl < minRecordTTL || ttl > maxRecordTTL {
+			diags.AddAttributeError(path.Root("ttl"), "Value out of range",
+				fmt.Sprintf("`ttl` must be between %d and %d seconds, got %d.", minRecordTTL, maxRecordTTL, ttl))
+		}
+	}
+
+	if rtype != "MX" && rtype != "SRV" && !config.Priority.IsNull() {
+		diags.AddAttributeError(path.Root("priority"), "Invalid attribute for record type",
+			"`priority` can only be used with type MX or SRV.")
+	}
+	if rtype == "MX" && !config.Priority.IsNull() && !config.Priority.IsUnknown() {
+		if p := config.Priority.ValueInt64(); p < 0 || p > maxSRVValue {
+			diags.AddAttributeError(path.Root("priority"), "Value out of range",
+				fmt.Sprintf("`priority` must be between 0 and %d, got %d.", maxSRVValue, p))
+		}
+	}
+
+	if rtype != "TXT" && !config.TXTStrings.IsNull() {
+		diags.AddAttributeError(path.Root("txt_strings"), "Invalid attribute for record type",
+			"`txt_strings` can only be used with type TXT.")
+	}
+
+	if rtype != "CAA" {
+		for name, v := range map[string]attr.Value{"caa_value": config.CAAValue, "caa_flags": config.CAAFlags, "caa_tag": config.CAATag} {
+			if !v.IsNull() {
+				diags.AddAttributeError(path.Root(name), "Invalid attribute for record type",
+					fmt.Sprintf("`%s` can only be used with type CAA.", name))
+			}
+		}
+	}
+
+	if rtype == "CNAME" && !config.Name.IsUnknown() && !config.Domain.IsUnknown() &&
+		relativeName(config.Name.ValueString(), config.Domain.ValueString()) == "" {
+		diags.AddAttributeError(path.Root("name"), "CNAME at zone apex",
+			"A CNAME record cannot be created at the zone apex because it would conflict with the SOA and NS records. Use an A/AAAA record instead.")
+	}
+
+	content := config.Content
+	switch rtype {
+	case "CAA":
+		validateCAAConfig(config, diags)
+		return
+	case "SRV":
+		// Checked by validateSRVConfig
+		return
+	case "TLSA", "SSHFP":
+		if config.hasHexRDataAttrs(rtype) {
+			// Checked by validateHexRDataConfig
+			return
+		}
+	case "TXT":
+		if !config.TXTStrings.IsNull() {
+			validateTXTStringsConfig(config, diags)
+			return
+		}
+	}
+	if content.IsUnknown() {
+		return
+	}
+	if content.IsNull() || content.ValueString() == "" {
+		diags.AddAttributeError(path.Root("content"), "Missing content",
+			fmt.Sprintf("The 'content' attribute is required for %s records.", rtype))
+		return
+	}
+
+	switch rtype {
+	case "A":
+		if addr, err := netip.ParseAddr(content.ValueString()); err != nil || !addr.Is4() {
+			diags.AddAttributeError(path.Root("content"), "Invalid IPv4 address",
+				fmt.Sprintf("%q is not a valid IPv4 address.", content.ValueString()))
+		}
+	case "AAAA":
+		if addr, err := netip.ParseAddr(content.ValueString()); err != nil || !addr.Is6() || addr.Is4In6() || addr.Zone() != "" {
+			diags.AddAttributeError(path.Root("content"), "Invalid IPv6 address",
+				fmt.Sprintf("%q is not a valid IPv6 address.", content.ValueString()))
+		}
+	}
+}
+
+// validateNameConfig warns when name repeats the zone without the trailing
+// dot. It is accepted as a fully qualified name, but in zone-file syntax it
+// would be relative and name a record one level deeper.
+func validateNameConfig(name, domain types.String, diags *diag.Diagnostics) {
+	if name.IsUnknown() || name.IsNull() || domain.IsUnknown() || domain.IsNull() {
+		return
+	}
+	if !nameIncludesZone(name.ValueString(), domain.ValueString()) {
+		return
+	}
+	rel := displayName(name.ValueString(), domain.ValueString())
+	diags.AddAttributeWarning(path.Root("name"), "Name contains the zone",
+		fmt.Sprintf("%q already contains the zone %q and is treated as the fully qualified name %q. "+
+			"Use the relative name %q, or add a trailing dot to make the fully qualified name explicit.",
+			name.ValueString(), domain.ValueString(), fqdn(name.ValueString(), domain.ValueString())+".", rel))
+}
+
+// validateCAAConfig checks the caa_* attributes of a CAA record.
+func validateCAAConfig(config *dnsRecordModel, diags *diag.Diagnostics) {
+	if config.CAATag.IsNull() {
+		diags.AddAttributeError(path.Root("caa_tag"), "Missing CAA attribute", "`caa_tag` is required for CAA records.")
+	} else if !config.CAATag.IsUnknown() && !containsString(caaTags, strings.ToLower(config.CAATag.ValueString())) {
+		diags.AddAttributeError(path.Root("caa_tag"), "Invalid CAA tag",
+			fmt.Sprintf("%q is not a CAA property tag. Valid tags: %s.", config.CAATag.ValueString(), strings.Join(caaTags, ", ")))
+	}
+	if config.CAAValue.IsNull() && (config.Content.IsNull() || config.Content.ValueString() == "") {
+		diags.AddAttributeError(path.Root("caa_value"), "Missing CAA attribute", "`caa_value` is required for CAA records.")
+	}
+	if !config.CAAFlags.IsNull() && !config.CAAFlags.IsUnknown() {
+		if f := config.CAAFlags.ValueInt64(); f < 0 || f > 255 {
+			diags.AddAttributeError(path.Root("caa_flags"), "Value out of range",
+				fmt.Sprintf("`caa_flags` must be between 0 and 255, got %d.", f))
+		}
+	}
+}
+
+// validateTXTStringsConfig checks txt_strings of a TXT record.
+func validateTXTStringsConfig(config *dnsRecordModel, diags *diag.Diagnostics) {
+	if !config.Content.IsNull() {
+		diags.AddAttributeError(path.Root("content"), "Conflicting TXT attributes",
+			"Set either `content` or `txt_strings`, not both.")
+		return
+	}
+	if config.TXTStrings.IsUnknown() {
+		return
+	}
+	elems := config.TXTStrings.Elements()
+	if len(elems) == 0 {
+		diags.AddAttributeError(path.Root("txt_strings"), "Missing TXT value", "`txt_strings` must contain at least one string.")
+	}
+	for i, v := range elems {
+		if s, ok := v.(types.String); ok && !s.IsUnknown() && len(s.ValueString()) > maxTXTStringLength {
+			diags.AddAttributeError(path.Root("txt_strings").AtListIndex(i), "TXT string too long",
+				fmt.Sprintf("Each TXT string can be at most %d bytes, got %d. Split it into several strings or use `content`, which is split automatically.", maxTXTStringLength, len(s.ValueString())))
+		}
+	}
+}
+
+// validateSRVConfig checks the srv_* attributes and SRV content of an
+// active24_dns_record configuration.
+func validateSRVConfig(config *dnsRecordModel, diags *diag.Diagnostics) {
+	srvAttrs := []struct {
+		name  string
+		value attr.Value
+	}{
+		{"srv_service", config.SRVService},
+		{"srv_protocol", config.SRVProtocol},
+		{"srv_weight", config.SRVWeight},
+		{"srv_port", config.SRVPort},
+		{"srv_target", config.SRVTarget},
+	}
+	if !strings.EqualFold(config.Type.ValueString(), "SRV") {
+		for _, a := range srvAttrs {
+			if !a.value.IsNull() {
+				diags.AddAttributeError(path.Root(a.name), "Invalid attribute for record type",
+					fmt.Sprintf("`%s` can only be used with type SRV.", a.name))
+			}
+		}
+		return
+	}
+
+	// Service and protocol labels
+	if config.SRVService.IsNull() != config.SRVProtocol.IsNull() {
+		diags.AddAttributeError(path.Root("srv_service"), "Incomplete SRV name",
+			"`srv_service` and `srv_protocol` must be set together.")
+	}
+	for name, v := range map[string]types.String{"srv_service": config.SRVService, "srv_protocol": config.SRVProtocol} {
+		if !v.IsNull() && !v.IsUnknown() && !srvLabelRe.MatchString(v.ValueString()) {
+			diags.AddAttributeError(path.Root(name), "Invalid SRV label",
+				fmt.Sprintf("%q is not a valid label. Use letters, digits and hyphens, optionally prefixed with `_`.", v.ValueString()))
+		}
+	}
+
+	// Numeric fields
+	for name, v := range map[string]types.Int64{"priority": config.Priority, "srv_weight": config.SRVWeight, "srv_port": config.SRVPort} {
+		if !v.IsNull() && !v.IsUnknown() && (v.ValueInt64() < 0 || v.ValueInt64() > maxSRVValue) {
+			diags.AddAttributeError(path.Root(name), "Value out of range",
+				fmt.Sprintf("`%s` must be between 0 and %d, got %d.", name, maxSRVValue, v.ValueInt64()))
+		}
+	}
+
+	structured := !config.SRVWeight.IsNull() || !config.SRVPort.IsNull() || !config.SRVTarget.IsNull()
+	switch {
+	case structured && !config.Content.IsNull():
+		diags.AddAttributeError(path.Root("content"), "Conflicting SRV attributes",
+			"Set either `content` or `srv_weight`, `srv_port` and `srv_target`, not both.")
+	case structured:
+		for name, v := range map[string]attr.Value{"srv_weight": config.SRVWeight, "srv_port": config.SRVPort, "srv_target": config.SRVTarget, "priority": config.Priority} {
+			if v.IsNull() {
+				diags.AddAttributeError(path.Root(name), "Missing SRV attribute",
+					fmt.Sprintf("`%s` is required for SRV records.", name))
+			}
+		}
+	case config.Content.IsUnknown():
+	case config.Content.IsNull():
+		diags.AddAttributeError(path.Root("srv_target"), "Missing SRV attribute",
+			"SRV records need `srv_weight`, `srv_port`, `srv_target` and `priority`.")
+	default:
+		srv, err := parseSRVContent(config.Content.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("content"), "Invalid SRV content", err.Error())
+		} else if srv.Priority == nil && config.Priority.IsNull() {
+			diags.AddAttributeError(path.Root("priority"), "Missing SRV attribute",
+				"`priority` is required for SRV records.")
+		} else if srv.Priority != nil && !config.Priority.IsNull() {
+			diags.AddAttributeError(path.Root("priority"), "Conflicting SRV attributes",
+				"The priority is already part of `content`. Remove `priority` or the first number of `content`.")
+		}
+	}
+}
+
+// validateHexRDataConfig checks the tlsa_* and sshfp_* attributes and the
+// content of TLSA and SSHFP records.
+func validateHexRDataConfig(config *dnsRecordModel, diags *diag.Diagnostics) {
+	rtype := strings.ToUpper(config.Type.ValueString())
+	for _, specType := range []string{"SSHFP", "TLSA"} {
+		spec := hexRDataSpecs[specType]
+		if specType == rtype {
+			continue
+		}
+		numbers, data := config.hexRDataAttrs(specType)
+		for i, v := range numbers {
+			if !v.IsNull() {
+				diags.AddAttributeError(path.Root(spec.Numbers[i]), "Invalid attribute for record type",
+					fmt.Sprintf("`%s` can only be used with type %s.", spec.Numbers[i], specType))
+			}
+		}
+		if !data.IsNull() {
+			diags.AddAttributeError(path.Root(spec.Data), "Invalid attribute for record type",
+				fmt.Sprintf("`%s` can only be used with type %s.", spec.Data, specType))
+		}
+	}
+
+	spec, ok := hexRDataSpecs[rtype]
+	if !ok {
+		return
+	}
+	numbers, data := config.hexRDataAttrs(rtype)
+	typed := config.hasHexRDataAttrs(rtype)
+
+	switch {
+	case typed && !config.Content.IsNull():
+		diags.AddAttributeError(path.Root("content"), fmt.Sprintf("Conflicting %s attributes", rtype),
+			fmt.Sprintf("Set either `content` or the %s attributes, not both.", strings.ToLower(rtype)+"_*"))
+	case typed:
+		d := hexRData{Numbers: make([]int64, len(numbers)), Hex: data.ValueString()}
+		complete := true
+		for i, v := range numbers {
+			if v.IsNull() {
+				diags.AddAttributeError(path.Root(spec.Numbers[i]), fmt.Sprintf("Missing %s attribute", rtype),
+					fmt.Sprintf("`%s` is required for %s records.", spec.Numbers[i], rtype))
+			}
+			complete = complete && !v.IsNull() && !v.IsUnknown()
+			d.Numbers[i] = v.ValueInt64()
+		}
+		if data.IsNull() {
+			diags.AddAttributeError(path.Root(spec.Data), fmt.Sprintf("Missing %s attribute", rtype),
+				fmt.Sprintf("`%s` is required for %s records.", spec.Data, rtype))
+		}
+		if !complete || data.IsNull() || data.IsUnknown() {
+			return
+		}
+		if field, err := spec.check(d); err != nil {
+			attrName := spec.Data
+			if field < len(spec.Numbers) {
+				attrName = spec.Numbers[field]
+			}
+			diags.AddAttributeError(path.Root(attrName), fmt.Sprintf("Invalid %s attribute", rtype), err.Error())
+		}
+	case config.Content.IsNull() || config.Content.IsUnknown():
+	default:
+		d, err := parseHexRData(rtype, config.Content.ValueString())
+		if err == nil {
+			_, err = spec.check(d)
+		}
+		if err != nil {
+			diags.AddAttributeError(path.Root("content"), fmt.Sprintf("Invalid %s content", rtype), err.Error())
+		}
+	}
+}
+
+func containsString(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -2,10 +2,14 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -18,13 +22,81 @@ import (
 // Ensure resource implementation
 var _ resource.Resource = &dnsRecordResource{}
 var _ resource.ResourceWithImportState = &dnsRecordResource{}
+var _ resource.ResourceWithValidateConfig = &dnsRecordResource{}
 
 func NewDNSRecordResource() resource.Resource {
 	return &dnsRecordResource{}
 }
 
 type dnsRecordResource struct {
-	client *Client
+	client DNSAPI
+}
+
+// typeChanged requires replacement only for a real type change, since the
+// API may return the type in a different case than configured.
+func typeChanged(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !strings.EqualFold(req.StateValue.ValueString(), req.PlanValue.ValueString())
+}
+
+// recordNameModifier computes name_unicode and fqdn from the planned record,
+// so they are known at plan time instead of showing as (known after apply).
+type recordNameModifier struct {
+	description string
+	compute     func(m *dnsRecordModel) string
+}
+
+func (m recordNameModifier) Description(context.Context) string {
+	return m.description
+}
+
+func (m recordNameModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m recordNameModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var plan dnsRecordModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Name.IsUnknown() || plan.Domain.IsUnknown() || plan.Type.IsUnknown() ||
+		plan.SRVService.IsUnknown() || plan.SRVProtocol.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	resp.PlanValue = types.StringValue(m.compute(&plan))
+}
+
+var (
+	nameUnicodeModifier = recordNameModifier{
+		description: "Computes the Unicode form of the record name.",
+		compute: func(m *dnsRecordModel) string {
+			return unicodeName(m.Name.ValueString(), m.Domain.ValueString())
+		},
+	}
+	fqdnModifier = recordNameModifier{
+		description: "Computes the fully qualified record name.",
+		compute:     (*dnsRecordModel).fqdn,
+	}
+)
+
+// srvLabelRe matches an SRV service or protocol label, with or without the
+// leading underscore.
+var srvLabelRe = regexp.MustCompile(`^_?[A-Za-z0-9][A-Za-z0-9-]*$`)
+
+// dnsRecordFieldPaths maps Active24 API payload fields to resource attributes
+// so validation errors can be reported on the offending attribute.
+var dnsRecordFieldPaths = map[string]path.Path{
+	"name":     path.Root("name"),
+	"type":     path.Root("type"),
+	"content":  path.Root("content"),
+	"ttl":      path.Root("ttl"),
+	"priority": path.Root("priority"),
+	"caaValue": path.Root("caa_value"),
+	"flags":    path.Root("caa_flags"),
+	"tag":      path.Root("caa_tag"),
+	"weight":   path.Root("srv_weight"),
+	"port":     path.Root("srv_port"),
 }
 
 type dnsRecordModel struct {
@@ -39,13 +111,35 @@ type dnsRecordModel struct {
 	CAAValue types.String `tfsdk:"caa_value"`
 	CAAFlags types.Int64  `tfsdk:"caa_flags"`
 	CAATag   types.String `tfsdk:"caa_tag"`
+
+	SRVService  types.String `tfsdk:"srv_service"`
+	SRVProtocol types.String `tfsdk:"srv_protocol"`
+	SRVWeight   types.Int64  `tfsdk:"srv_weight"`
+	SRVPort     types.Int64  `tfsdk:"srv_port"`
+	SRVTarget   types.String `tfsdk:"srv_target"`
+
+	TLSAUsage        types.Int64  `tfsdk:"tlsa_usage"`
+	TLSASelector     types.Int64  `tfsdk:"tlsa_selector"`
+	TLSAMatchingType types.Int64  `tfsdk:"tlsa_matching_type"`
+	TLSACertData     types.String `tfsdk:"tlsa_cert_data"`
+
+	SSHFPAlgorithm       types.Int64  `tfsdk:"sshfp_algorithm"`
+	SSHFPFingerprintType types.Int64  `tfsdk:"sshfp_fingerprint_type"`
+	SSHFPFingerprint     types.String `tfsdk:"sshfp_fingerprint"`
+
+	TXTStrings types.List `tfsdk:"txt_strings"`
+
+	NameUnicode types.String `tfsdk:"name_unicode"`
+	FQDN        types.String `tfsdk:"fqdn"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *dnsRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_dns_record"
 }
 
-func (r *dnsRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *dnsRecordResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -57,18 +151,43 @@ func (r *dnsRecordResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"domain": schema.StringAttribute{
 				Required:    true,
 				Description: "Domain name owning the record (zone)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"service": schema.StringAttribute{
-				Optional:    true,
-				Description: "Active24 v2 service key (if different from domain). If set, this overrides domain in API path /v2/service/{service}",
+				Optional:      true,
+				Computed:      true,
+				Description:   "Active24 v2 service ID. Resolved from the account's service list by domain when not set",
+				PlanModifiers: servicePlanModifiers(),
+				Validators:    serviceValidators(),
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
-				Description: "Record name (relative or FQDN depending on API)",
+				Description: "Record name (relative or FQDN depending on API). Internationalized names may be given in Unicode or punycode",
+			},
+			"fqdn": schema.StringAttribute{
+				Computed:    true,
+				Description: "Fully qualified record name in A-label form without the trailing dot, including the SRV `_service._proto` prefix",
+				PlanModifiers: []planmodifier.String{
+					fqdnModifier,
+				},
+			},
+			"name_unicode": schema.StringAttribute{
+				Computed:    true,
+				Description: "Record name relative to the zone in Unicode form, `@` for the apex",
+				PlanModifiers: []planmodifier.String{
+					nameUnicodeModifier,
+				},
 			},
 			"type": schema.StringAttribute{
 				Required:    true,
 				Description: "DNS record type (A, AAAA, CNAME, TXT, MX, CAA, etc.)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(typeChanged,
+						"Changing the record type forces a new record.",
+						"Changing the record type forces a new record."),
+				},
 			},
 			"content": schema.StringAttribute{
 				Optional:    true,
@@ -95,34 +214,105 @@ func (r *dnsRecordResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Optional:    true,
 				Description: "Tag for CAA record",
 			},
+			"srv_service": schema.StringAttribute{
+				Optional:    true,
+				Description: "SRV service label (e.g. `_sip`). Together with srv_protocol it is prepended to name as `_service._proto.name`",
+			},
+			"srv_protocol": schema.StringAttribute{
+				Optional:    true,
+				Description: "SRV protocol label (e.g. `_tcp`)",
+			},
+			"srv_weight": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Weight for SRV record",
+			},
+			"srv_port": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Port for SRV record",
+			},
+			"srv_target": schema.StringAttribute{
+				Optional:    true,
+				Description: "Target host for SRV record (replaces content)",
+			},
+			"tlsa_usage": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Certificate usage for TLSA record (0-3)",
+			},
+			"tlsa_selector": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Selector for TLSA record (0 full certificate, 1 public key)",
+			},
+			"tlsa_matching_type": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Matching type for TLSA record (0 exact, 1 SHA-256, 2 SHA-512)",
+			},
+			"tlsa_cert_data": schema.StringAttribute{
+				Optional:    true,
+				Description: "Certificate association data for TLSA record, hex encoded (replaces content)",
+			},
+			"sshfp_algorithm": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Key algorithm for SSHFP record (1 RSA, 2 DSA, 3 ECDSA, 4 Ed25519, 6 Ed448)",
+			},
+			"sshfp_fingerprint_type": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Fingerprint type for SSHFP record (1 SHA-1, 2 SHA-256)",
+			},
+			"sshfp_fingerprint": schema.StringAttribute{
+				Optional:    true,
+				Description: "Host key fingerprint for SSHFP record, hex encoded (replaces content)",
+			},
+			"txt_strings": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Character-strings of a TXT record, each up to 255 bytes (replaces content)",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
 
-func (r *dnsRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+func (r *dnsRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*Client)
+	client, ok := req.ProviderData.(DNSAPI)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("Expected provider.DNSAPI, got %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = client
+}
+
+func (r *dnsRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config dnsRecordModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config.Type.IsUnknown() {
+		return
+	}
+
+	validateRecordConfig(&config, &resp.Diagnostics)
+	validateSRVConfig(&config, &resp.Diagnostics)
+	validateHexRDataConfig(&config, &resp.Diagnostics)
 }
 
 func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan dnsRecordModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, defaultRecordTimeout, &resp.Diagnostics)
+	defer cancel()
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Basic validation: content is required for non-CAA records
-	if plan.Type.ValueString() != "CAA" && (plan.Content.IsNull() || plan.Content.ValueString() == "") {
-		resp.Diagnostics.AddError("Missing content", "The 'content' attribute is required for non-CAA records.")
-		return
-	}
-
-	createReq := createRecordRequest{
-		Name:    normalizeNameForAPI(plan.Name.ValueString()),
-		Type:    plan.Type.ValueString(),
+	createReq := RecordRequest{
+		Name:    plan.ownerName(),
+		Type:    strings.ToUpper(plan.Type.ValueString()),
 		Content: plan.Content.ValueString(),
 		TTL:     plan.TTL.ValueInt64(),
 	}
@@ -132,7 +322,7 @@ func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	// Send CAA fields ONLY for CAA record type to avoid API validation errors
-	if plan.Type.ValueString() == "CAA" {
+	if strings.EqualFold(plan.Type.ValueString(), "CAA") {
 		if !plan.CAAValue.IsNull() && !plan.CAAValue.IsUnknown() {
 			createReq.CAAValue = plan.CAAValue.ValueString()
 		}
@@ -149,15 +339,20 @@ func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 			createReq.Content = plan.CAAValue.ValueString()
 		}
 	}
+	if err := plan.applyTypedFields(&createReq); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid SRV content", err.Error())
+		return
+	}
+	createReq.Content = contentForAPI(createReq.Type, createReq.Content)
 
-	targetService := plan.Domain.ValueString()
-	if !plan.Service.IsNull() && !plan.Service.IsUnknown() && plan.Service.ValueString() != "" {
-		targetService = plan.Service.ValueString()
+	if !resolveServiceAttr(ctx, r.client, plan.Domain, &plan.Service, &resp.Diagnostics) {
+		return
 	}
+	targetService := zoneTargetService(plan.Domain, plan.Service)
 
 	createdRec, err := r.client.CreateRecord(ctx, targetService, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating record", err.Error())
+		appendAPIError(&resp.Diagnostics, "Error creating record", err, dnsRecordFieldPaths)
 		return
 	}
 
@@ -166,25 +361,19 @@ func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 	if rec == nil || rec.ID == 0 {
 		// Fallback: search by name+type, then match by exact name + content.
 		// Active24 API filters by substring, so we must do exact name matching client-side.
-		records, err := r.client.ListRecords(ctx, targetService, normalizeNameForAPI(plan.Name.ValueString()), plan.Type.ValueString(), "", nil)
+		records, err := r.client.ListRecords(ctx, targetService, createReq.Name, createReq.Type, "", nil)
 		if err != nil || len(records) == 0 {
 			resp.Diagnostics.AddError("Error reading created record", fmt.Sprintf("lookup failed: %v", err))
 			return
 		}
 		matchContent := createReq.Content
-		matchName := normalizeNameForAPI(plan.Name.ValueString())
-		domain := plan.Domain.ValueString()
-		fqdnSuffix := "." + domain
+		rec = nil
 
 		for i := range records {
-			recName := records[i].Name
-			if strings.HasSuffix(recName, fqdnSuffix) {
-				recName = strings.TrimSuffix(recName, fqdnSuffix)
-			}
-			if recName != matchName {
+			if !sameName(records[i].Name, createReq.Name, plan.Domain.ValueString()) {
 				continue
 			}
-			if records[i].Content == matchContent || records[i].CAAValue == matchContent {
+			if contentEqual(records[i].Type, records[i].Content, matchContent) || records[i].CAAValue == matchContent {
 				rec = &records[i]
 				break
 			}
@@ -200,7 +389,9 @@ func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 	if rec.TTL > 0 {
 		plan.TTL = types.Int64Value(rec.TTL)
 	}
-	if rec.Priority != nil {
+	if plan.srvPriorityInContent() {
+		// Priority stays part of content
+	} else if rec.Priority != nil {
 		plan.Priority = types.Int64Value(*rec.Priority)
 	} else {
 		plan.Priority = types.Int64Null()
@@ -228,6 +419,8 @@ func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 	var state dnsRecordModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, defaultRecordTimeout, &resp.Diagnostics)
+	defer cancel()
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -238,16 +431,27 @@ func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	targetService := state.Domain.ValueString()
-	if !state.Service.IsNull() && !state.Service.IsUnknown() && state.Service.ValueString() != "" {
-		targetService = state.Service.ValueString()
+	if !resolveServiceAttr(ctx, r.client, state.Domain, &state.Service, &resp.Diagnostics) {
+		return
 	}
+	targetService := zoneTargetService(state.Domain, state.Service)
 
 	// Try to get record by ID directly
 	rec, err := r.client.GetRecord(ctx, targetService, id)
 	if err != nil {
+		// Authentication, rate limit and network failures must not be mistaken
+		// for a deleted record.
+		if !IsNotFound(err) && !IsValidation(err) {
+			resp.Diagnostics.AddError("Error reading record", err.Error())
+			return
+		}
 		// Fallback: try list records if GetRecord fails (some record types/APIs might behave differently)
-		records, _ := r.client.ListRecords(ctx, targetService, normalizeNameForAPI(state.Name.ValueString()), state.Type.ValueString(), "", nil)
+		records, err := r.client.ListRecords(ctx, targetService, state.ownerName(), state.Type.ValueString(), "", nil)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading record", err.Error())
+			return
+		}
+		rec = nil
 		for i := range records {
 			if records[i].ID == id {
 				rec = &records[i]
@@ -262,20 +466,23 @@ func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// If API returns FQDN, strip the domain part to match relative names in TF config
-	apiName := rec.Name
-	domain := state.Domain.ValueString()
-
-	if apiName == domain || apiName == domain+"." {
-		apiName = "" // will be "@" after denormalizeNameFromAPI
-	} else {
-		suffix := "." + domain
-		if strings.HasSuffix(apiName, suffix) {
-			apiName = strings.TrimSuffix(apiName, suffix)
+	name := displayName(rec.Name, state.Domain.ValueString())
+	if !state.SRVService.IsNull() && !state.SRVProtocol.IsNull() {
+		// Strip the composed _service._proto prefix again
+		if base, ok := splitSRVName(relativeName(rec.Name, state.Domain.ValueString()), state.SRVService.ValueString(), state.SRVProtocol.ValueString()); ok {
+			name = denormalizeNameFromAPI(base)
 		}
 	}
-
-	state.Name = types.StringValue(denormalizeNameFromAPI(apiName))
-	state.Type = types.StringValue(rec.Type)
+	// Keep the configured spelling (FQDN, Unicode instead of punycode). Import
+	// by ID has no name yet, so the apex is set to "@" rather than left empty.
+	if state.Name.IsNull() || !sameName(state.Name.ValueString(), name, state.Domain.ValueString()) {
+		state.Name = types.StringValue(name)
+	}
+	state.NameUnicode = types.StringValue(unicodeName(state.Name.ValueString(), state.Domain.ValueString()))
+	state.FQDN = types.StringValue(fqdn(rec.Name, state.Domain.ValueString()))
+	if !strings.EqualFold(state.Type.ValueString(), rec.Type) {
+		state.Type = types.StringValue(rec.Type)
+	}
 	state.TTL = types.Int64Value(rec.TTL)
 
 	if rec.Priority != nil {
@@ -288,7 +495,9 @@ func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		// For CAA records: populate caa_* fields from API.
 		// Do NOT set content in state - it is only used internally for API calls.
 		if rec.CAAValue != "" {
-			state.CAAValue = types.StringValue(rec.CAAValue)
+			if !contentEqual("CAA", state.CAAValue.ValueString(), rec.CAAValue) {
+				state.CAAValue = types.StringValue(rec.CAAValue)
+			}
 		} else if rec.Content != "" && (state.CAAValue.IsNull() || state.CAAValue.ValueString() == "") {
 			// API didn't return caaValue separately, use content as fallback
 			state.CAAValue = types.StringValue(rec.Content)
@@ -298,18 +507,44 @@ func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		} else if state.CAAFlags.IsNull() {
 			state.CAAFlags = types.Int64Value(0)
 		}
-		if rec.Tag != "" {
+		if rec.Tag != "" && !strings.EqualFold(state.CAATag.ValueString(), rec.Tag) {
 			state.CAATag = types.StringValue(rec.Tag)
 		}
 		// Keep content null for CAA - user should not need to set it
 		state.Content = types.StringNull()
+		state.clearSRV()
+		state.clearHexRData()
+	} else if strings.EqualFold(rec.Type, "SRV") {
+		state.readSRV(rec)
+		state.clearHexRData()
+		state.CAAValue = types.StringNull()
+		state.CAAFlags = types.Int64Null()
+		state.CAATag = types.StringNull()
+	} else if strings.EqualFold(rec.Type, "TXT") {
+		state.readTXT(rec)
+		state.clearSRV()
+		state.clearHexRData()
+		state.CAAValue = types.StringNull()
+		state.CAAFlags = types.Int64Null()
+		state.CAATag = types.StringNull()
+	} else if _, ok := hexRDataSpecs[strings.ToUpper(rec.Type)]; ok {
+		state.readHexRData(rec)
+		state.clearSRV()
+		state.CAAValue = types.StringNull()
+		state.CAAFlags = types.Int64Null()
+		state.CAATag = types.StringNull()
 	} else {
-		// For all other record types: always sync content from API
-		state.Content = types.StringValue(rec.Content)
+		// For all other record types: sync content from API, keeping the
+		// configured spelling of an equivalent value
+		if state.Content.IsNull() || !contentEqual(rec.Type, state.Content.ValueString(), rec.Content) {
+			state.Content = types.StringValue(rec.Content)
+		}
 		// Ensure CAA fields are null for non-CAA records
 		state.CAAValue = types.StringNull()
 		state.CAAFlags = types.Int64Null()
 		state.CAATag = types.StringNull()
+		state.clearSRV()
+		state.clearHexRData()
 	}
 
 	diags = resp.State.Set(ctx, &state)
@@ -317,28 +552,28 @@ func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan dnsRecordModel
+	var plan, state dnsRecordModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, defaultRecordTimeout, &resp.Diagnostics)
+	defer cancel()
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Basic validation: content is required for non-CAA records
-	if plan.Type.ValueString() != "CAA" && (plan.Content.IsNull() || plan.Content.ValueString() == "") {
-		resp.Diagnostics.AddError("Missing content", "The 'content' attribute is required for non-CAA records.")
-		return
-	}
-
-	id, err := strconv.ParseInt(plan.ID.ValueString(), 10, 64)
+	// domain, service and type force replacement, so the record always
+	// stays in the service it was created in
+	id, err := strconv.ParseInt(state.ID.ValueString(), 10, 64)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid ID", err.Error())
 		return
 	}
 
-	updateReq := createRecordRequest{
-		Name:    normalizeNameForAPI(plan.Name.ValueString()),
-		Type:    plan.Type.ValueString(),
+	updateReq := RecordRequest{
+		Name:    plan.ownerName(),
+		Type:    strings.ToUpper(plan.Type.ValueString()),
 		Content: plan.Content.ValueString(),
 		TTL:     plan.TTL.ValueInt64(),
 	}
@@ -348,7 +583,7 @@ func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	// Send CAA fields ONLY for CAA record type to avoid API validation errors
-	if plan.Type.ValueString() == "CAA" {
+	if strings.EqualFold(plan.Type.ValueString(), "CAA") {
 		if !plan.CAAValue.IsNull() && !plan.CAAValue.IsUnknown() {
 			updateReq.CAAValue = plan.CAAValue.ValueString()
 		}
@@ -365,14 +600,27 @@ func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateReque
 			updateReq.Content = plan.CAAValue.ValueString()
 		}
 	}
+	if err := plan.applyTypedFields(&updateReq); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid SRV content", err.Error())
+		return
+	}
+	updateReq.Content = contentForAPI(updateReq.Type, updateReq.Content)
 
-	targetService := plan.Domain.ValueString()
-	if !plan.Service.IsNull() && !plan.Service.IsUnknown() && plan.Service.ValueString() != "" {
-		targetService = plan.Service.ValueString()
+	if !resolveServiceAttr(ctx, r.client, state.Domain, &state.Service, &resp.Diagnostics) {
+		return
 	}
+	if plan.Service.IsUnknown() {
+		plan.Service = state.Service
+	}
+	targetService := zoneTargetService(state.Domain, state.Service)
 	updatedRec, err := r.client.UpdateRecord(ctx, targetService, id, updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating record", err.Error())
+		if IsNotFound(err) {
+			resp.Diagnostics.AddError("Error updating record",
+				fmt.Sprintf("Record %d no longer exists in service %s. Run `terraform refresh` or re-apply to recreate it.", id, targetService))
+			return
+		}
+		appendAPIError(&resp.Diagnostics, "Error updating record", err, dnsRecordFieldPaths)
 		return
 	}
 
@@ -387,7 +635,9 @@ func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateReque
 		if rec.TTL > 0 {
 			plan.TTL = types.Int64Value(rec.TTL)
 		}
-		if rec.Priority != nil {
+		if plan.srvPriorityInContent() {
+			// Priority stays part of content
+		} else if rec.Priority != nil {
 			plan.Priority = types.Int64Value(*rec.Priority)
 		} else {
 			plan.Priority = types.Int64Null()
@@ -416,6 +666,8 @@ func (r *dnsRecordResource) Delete(ctx context.Context, req resource.DeleteReque
 	var state dnsRecordModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, defaultRecordTimeout, &resp.Diagnostics)
+	defer cancel()
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -426,11 +678,15 @@ func (r *dnsRecordResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	targetService := state.Domain.ValueString()
-	if !state.Service.IsNull() && !state.Service.IsUnknown() && state.Service.ValueString() != "" {
-		targetService = state.Service.ValueString()
+	if !resolveServiceAttr(ctx, r.client, state.Domain, &state.Service, &resp.Diagnostics) {
+		return
 	}
+	targetService := zoneTargetService(state.Domain, state.Service)
 	if err := r.client.DeleteRecord(ctx, targetService, id); err != nil {
+		if IsNotFound(err) {
+			// Already gone
+			return
+		}
 		resp.Diagnostics.AddError("Error deleting record", err.Error())
 		return
 	}
@@ -492,95 +748,276 @@ func (r *dnsRecordResource) ImportState(ctx context.Context, req resource.Import
 // importByNameType looks up a record by name and type via the API, then sets the state.
 // If content is non-empty, it is used to disambiguate when multiple records match name+type.
 func (r *dnsRecordResource) importByNameType(ctx context.Context, domain, service, name, rtype, content string, resp *resource.ImportStateResponse) {
-	targetService := domain
-	if service != "" {
-		targetService = service
+	targetService := service
+	if targetService == "" {
+		resolved, err := r.client.ResolveService(ctx, domain)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to determine service", err.Error())
+			return
+		}
+		targetService = resolved
 	}
+	unicode := !isASCII(name)
+	name = relativeName(name, domain)
 
-	// Normalize "@" to empty string for API
-	if name == "@" {
-		name = ""
+	found, err := findRecordByNameType(ctx, r.client, domain, targetService, name, rtype, content)
+	if err != nil {
+		var lookupErr *recordLookupError
+		if !errors.As(err, &lookupErr) {
+			resp.Diagnostics.AddError("Error looking up record", err.Error())
+			return
+		}
+		if lookupErr.ambiguous() {
+			// No content filter - show all matches so user can pick
+			resp.Diagnostics.AddError("Multiple records found",
+				fmt.Sprintf("Found %d %s records named '%s'. Use one of these formats to disambiguate:\n"+
+					"  <domain>:<service>:<name>:<type>:<content>\n"+
+					"  <domain>:<service>:<id>\n\n"+
+					"Matching records:\n%s", len(lookupErr.Matches), rtype, name, lookupErr.matchList()))
+			return
+		}
+		resp.Diagnostics.AddError("Record not found", lookupErr.Error())
+		return
 	}
 
-	// Strip domain suffix if user provided FQDN (e.g. "devtest.dev.finbricks.com" -> "devtest.dev")
-	fqdnSuffix := "." + domain
-	if strings.HasSuffix(name, fqdnSuffix) {
-		name = strings.TrimSuffix(name, fqdnSuffix)
-	} else if name == domain {
-		name = "" // apex
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service"), targetService)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fmt.Sprintf("%d", found.ID))...)
+	// Keep a Unicode name in Unicode so it matches the configuration
+	stateName := denormalizeNameFromAPI(name)
+	if unicode {
+		stateName = toUnicode(stateName)
 	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), stateName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), strings.ToUpper(rtype))...)
+}
 
-	records, err := r.client.ListRecords(ctx, targetService, name, strings.ToUpper(rtype), "", nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Error looking up record", fmt.Sprintf("API error: %v", err))
+// ownerName returns the record name as sent to the API: relative to the zone,
+// "" for the apex, with the _service._proto prefix of a structured SRV record.
+func (m *dnsRecordModel) ownerName() string {
+	name := relativeName(m.Name.ValueString(), m.Domain.ValueString())
+	if strings.EqualFold(m.Type.ValueString(), "SRV") && !m.SRVService.IsNull() && !m.SRVProtocol.IsNull() {
+		return srvName(m.SRVService.ValueString(), m.SRVProtocol.ValueString(), name)
+	}
+	return name
+}
+
+// fqdn returns the fully qualified name of the record.
+func (m *dnsRecordModel) fqdn() string {
+	return fqdn(m.ownerName(), m.Domain.ValueString())
+}
+
+// applyTypedFields fills the type-specific parts of req from the srv_*,
+// tlsa_* and sshfp_* attributes.
+func (m *dnsRecordModel) applyTypedFields(req *RecordRequest) error {
+	if d, ok := m.hexRData(); ok {
+		req.Content = d.String()
+		return nil
+	}
+	if strings.EqualFold(m.Type.ValueString(), "TXT") {
+		if parts, ok := m.txtStrings(); ok {
+			req.Content = formatCharacterStrings(parts)
+		} else {
+			req.Content = txtRequestContent(req.Content)
+		}
+		return nil
+	}
+	if !strings.EqualFold(m.Type.ValueString(), "SRV") {
+		return nil
+	}
+	if !m.SRVTarget.IsNull() {
+		req.Content = m.SRVTarget.ValueString()
+		req.Weight = m.SRVWeight.ValueInt64Pointer()
+		req.Port = m.SRVPort.ValueInt64Pointer()
+		return nil
+	}
+	return applySRVContent(req)
+}
+
+// txtStrings returns the elements of txt_strings. It reports false when the
+// attribute is not set.
+func (m *dnsRecordModel) txtStrings() ([]string, bool) {
+	if m.TXTStrings.IsNull() || m.TXTStrings.IsUnknown() {
+		return nil, false
+	}
+	var parts []string
+	for _, v := range m.TXTStrings.Elements() {
+		if s, ok := v.(types.String); ok {
+			parts = append(parts, s.ValueString())
+		}
+	}
+	return parts, true
+}
+
+// readTXT stores a TXT record from the API. Content sent in quoted chunks is
+// reassembled, so a long value reads back as configured.
+func (m *dnsRecordModel) readTXT(rec *DNSRecord) {
+	if m.Content.IsNull() && !m.TXTStrings.IsNull() {
+		parts, ok := parseCharacterStrings(rec.Content)
+		if !ok {
+			parts = []string{rec.Content}
+		}
+		if cur, _ := m.txtStrings(); !equalStrings(cur, parts) {
+			elems := make([]attr.Value, len(parts))
+			for i, p := range parts {
+				elems[i] = types.StringValue(p)
+			}
+			m.TXTStrings = types.ListValueMust(types.StringType, elems)
+		}
 		return
 	}
+	m.TXTStrings = types.ListNull(types.StringType)
+	if m.Content.IsNull() {
+		// Import: there is no configured spelling to keep
+		m.Content = types.StringValue(txtValue(rec.Content))
+	} else if !contentEqual("TXT", m.Content.ValueString(), rec.Content) {
+		m.Content = types.StringValue(rec.Content)
+	}
+}
 
-	// Find matching records by name and type
-	var matches []DNSRecord
-	for i := range records {
-		recName := records[i].Name
-		// Normalize: strip domain suffix from API response too
-		if strings.HasSuffix(recName, fqdnSuffix) {
-			recName = strings.TrimSuffix(recName, fqdnSuffix)
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
 		}
-		if recName == name && strings.EqualFold(records[i].Type, rtype) {
-			matches = append(matches, records[i])
+	}
+	return true
+}
+
+// readSRV stores an SRV record from the API in the form the configuration
+// uses: the srv_* attributes, or content in presentation form.
+func (m *dnsRecordModel) readSRV(rec *DNSRecord) {
+	srv := srvData{Target: rec.Content, Priority: rec.Priority}
+	if rec.Weight != nil && rec.Port != nil {
+		srv.Weight, srv.Port = *rec.Weight, *rec.Port
+	} else if parsed, err := parseSRVContent(rec.Content); err == nil {
+		srv.Weight, srv.Port, srv.Target = parsed.Weight, parsed.Port, parsed.Target
+	}
+
+	if m.Content.IsNull() {
+		m.SRVWeight = types.Int64Value(srv.Weight)
+		m.SRVPort = types.Int64Value(srv.Port)
+		if m.SRVTarget.IsNull() || normalizeHostname(m.SRVTarget.ValueString()) != normalizeHostname(srv.Target) {
+			m.SRVTarget = types.StringValue(srv.Target)
 		}
+		return
 	}
 
-	if len(matches) == 0 {
-		resp.Diagnostics.AddError("Record not found",
-			fmt.Sprintf("No %s record named '%s' found in zone %s (service %s)", rtype, name, domain, targetService))
+	// Keep the configured content (which may include the priority) when it
+	// describes the same record
+	if cur, err := parseSRVContent(m.Content.ValueString()); err == nil &&
+		cur.Weight == srv.Weight && cur.Port == srv.Port && normalizeHostname(cur.Target) == normalizeHostname(srv.Target) &&
+		(cur.Priority == nil || equalInt64Ptr(cur.Priority, srv.Priority)) {
+		if cur.Priority != nil {
+			m.Priority = types.Int64Null()
+		}
+		m.clearSRV()
 		return
 	}
+	m.Content = types.StringValue(formatSRVContent(srv.Weight, srv.Port, srv.Target))
+	m.clearSRV()
+}
 
-	var found *DNSRecord
+// srvPriorityInContent reports an SRV record whose content is written as
+// "priority weight port target" instead of using the priority attribute.
+func (m *dnsRecordModel) srvPriorityInContent() bool {
+	if !strings.EqualFold(m.Type.ValueString(), "SRV") || m.Content.IsNull() {
+		return false
+	}
+	srv, err := parseSRVContent(m.Content.ValueString())
+	return err == nil && srv.Priority != nil
+}
 
-	if len(matches) == 1 {
-		found = &matches[0]
-	} else {
-		// Multiple matches - try to disambiguate by content
-		if content != "" {
-			for i := range matches {
-				recContent := matches[i].Content
-				recCAAValue := matches[i].CAAValue
-				if strings.EqualFold(recContent, content) || strings.EqualFold(recCAAValue, content) {
-					found = &matches[i]
-					break
-				}
-			}
-			if found == nil {
-				resp.Diagnostics.AddError("Record not found",
-					fmt.Sprintf("Found %d %s records named '%s' but none matching content '%s'", len(matches), rtype, name, content))
-				return
-			}
-		} else {
-			// No content filter - show all matches so user can pick
-			var ids []string
-			for _, m := range matches {
-				detail := m.Content
-				if m.CAAValue != "" {
-					detail = fmt.Sprintf("%s (tag=%s)", m.CAAValue, m.Tag)
-				}
-				ids = append(ids, fmt.Sprintf("  ID %d: %s", m.ID, detail))
-			}
-			resp.Diagnostics.AddError("Multiple records found",
-				fmt.Sprintf("Found %d %s records named '%s'. Use one of these formats to disambiguate:\n"+
-					"  <domain>:<service>:<name>:<type>:<content>\n"+
-					"  <domain>:<service>:<id>\n\n"+
-					"Matching records:\n%s", len(matches), rtype, name, strings.Join(ids, "\n")))
+// hexRDataAttrs returns the typed attributes of a TLSA or SSHFP record.
+func (m *dnsRecordModel) hexRDataAttrs(rtype string) ([]types.Int64, types.String) {
+	switch strings.ToUpper(rtype) {
+	case "TLSA":
+		return []types.Int64{m.TLSAUsage, m.TLSASelector, m.TLSAMatchingType}, m.TLSACertData
+	case "SSHFP":
+		return []types.Int64{m.SSHFPAlgorithm, m.SSHFPFingerprintType}, m.SSHFPFingerprint
+	}
+	return nil, types.StringNull()
+}
+
+// hasHexRDataAttrs reports whether any tlsa_* or sshfp_* attribute of
+// rtype is set.
+func (m *dnsRecordModel) hasHexRDataAttrs(rtype string) bool {
+	numbers, data := m.hexRDataAttrs(rtype)
+	for _, v := range numbers {
+		if !v.IsNull() {
+			return true
+		}
+	}
+	return !data.IsNull()
+}
+
+// hexRData returns the record data given by the tlsa_* or sshfp_*
+// attributes. It reports false when the data attribute is not set.
+func (m *dnsRecordModel) hexRData() (hexRData, bool) {
+	numbers, data := m.hexRDataAttrs(m.Type.ValueString())
+	if data.IsNull() || data.IsUnknown() {
+		return hexRData{}, false
+	}
+	d := hexRData{Hex: data.ValueString()}
+	for _, v := range numbers {
+		d.Numbers = append(d.Numbers, v.ValueInt64())
+	}
+	return d, true
+}
+
+// readHexRData stores a TLSA or SSHFP record from the API in the form the
+// configuration uses. Hex data that differs only in case is not drift.
+func (m *dnsRecordModel) readHexRData(rec *DNSRecord) {
+	have, err := parseHexRData(rec.Type, rec.Content)
+	if err != nil {
+		m.Content = types.StringValue(rec.Content)
+		m.clearHexRData()
+		return
+	}
+
+	if m.Content.IsNull() {
+		if cur, ok := m.hexRData(); ok && cur.equal(have) {
 			return
 		}
+		m.clearHexRData()
+		switch strings.ToUpper(rec.Type) {
+		case "TLSA":
+			m.TLSAUsage = types.Int64Value(have.Numbers[0])
+			m.TLSASelector = types.Int64Value(have.Numbers[1])
+			m.TLSAMatchingType = types.Int64Value(have.Numbers[2])
+			m.TLSACertData = types.StringValue(have.Hex)
+		case "SSHFP":
+			m.SSHFPAlgorithm = types.Int64Value(have.Numbers[0])
+			m.SSHFPFingerprintType = types.Int64Value(have.Numbers[1])
+			m.SSHFPFingerprint = types.StringValue(have.Hex)
+		}
+		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
-	if service != "" {
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service"), service)...)
+	m.clearHexRData()
+	if cur, err := parseHexRData(rec.Type, m.Content.ValueString()); err == nil && cur.equal(have) {
+		return
 	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fmt.Sprintf("%d", found.ID))...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), denormalizeNameFromAPI(name))...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), strings.ToUpper(rtype))...)
+	m.Content = types.StringValue(rec.Content)
+}
+
+func (m *dnsRecordModel) clearHexRData() {
+	m.TLSAUsage = types.Int64Null()
+	m.TLSASelector = types.Int64Null()
+	m.TLSAMatchingType = types.Int64Null()
+	m.TLSACertData = types.StringNull()
+	m.SSHFPAlgorithm = types.Int64Null()
+	m.SSHFPFingerprintType = types.Int64Null()
+	m.SSHFPFingerprint = types.StringNull()
+}
+
+func (m *dnsRecordModel) clearSRV() {
+	m.SRVWeight = types.Int64Null()
+	m.SRVPort = types.Int64Null()
+	m.SRVTarget = types.StringNull()
 }
 
 // isDNSType checks if a string is a known DNS record type.
@@ -592,20 +1029,4 @@ func isDNSType(s string) bool {
 	return false
 }
 
-// normalizeNameForAPI converts Terraform-friendly apex marker "@" to the API's expected apex representation.
-func normalizeNameForAPI(name string) string {
-	if name == "@" {
-		return ""
-	}
-	return name
-}
-
-// denormalizeNameFromAPI converts API apex representation back to Terraform-friendly "@".
-func denormalizeNameFromAPI(name string) string {
-	if name == "" {
-		return "@"
-	}
-	return name
-}
-
 func ptrI(v int64) *int64 { return &v }
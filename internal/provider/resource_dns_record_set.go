@@ -0,0 +1,410 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure resource implementation
+var _ resource.Resource = &dnsRecordSetResource{}
+var _ resource.ResourceWithImportState = &dnsRecordSetResource{}
+var _ resource.ResourceWithValidateConfig = &dnsRecordSetResource{}
+
+func NewDNSRecordSetResource() resource.Resource {
+	return &dnsRecordSetResource{}
+}
+
+// dnsRecordSetResource manages all records of one name and type (an RRset).
+// Active24 has no RRset API, so the individual records are reconciled by value.
+type dnsRecordSetResource struct {
+	client DNSAPI
+}
+
+type dnsRecordSetModel struct {
+	ID      types.String              `tfsdk:"id"`
+	Service types.String              `tfsdk:"service"`
+	Domain  types.String              `tfsdk:"domain"`
+	Name    types.String              `tfsdk:"name"`
+	Type    types.String              `tfsdk:"type"`
+	TTL     types.Int64               `tfsdk:"ttl"`
+	Records []dnsRecordSetRecordModel `tfsdk:"records"`
+	FQDN    types.String              `tfsdk:"fqdn"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type dnsRecordSetRecordModel struct {
+	Content  types.String `tfsdk:"content"`
+	Priority types.Int64  `tfsdk:"priority"`
+	CAAValue types.String `tfsdk:"caa_value"`
+	CAAFlags types.Int64  `tfsdk:"caa_flags"`
+	CAATag   types.String `tfsdk:"caa_tag"`
+}
+
+func (r *dnsRecordSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record_set"
+}
+
+func (r *dnsRecordSetResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages all values of one record name and type, e.g. round-robin A records or several CAA/MX values.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:    true,
+				Description: "Domain name owning the records (zone)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Active24 v2 service ID. Resolved from the account's service list by domain when not set",
+				PlanModifiers: servicePlanModifiers(),
+				Validators:    serviceValidators(),
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Record name relative to the zone, `@` for the apex",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:    true,
+				Description: "DNS record type (A, AAAA, TXT, MX, CAA, etc.)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(typeChanged,
+						"Changing the record type forces a new record set.",
+						"Changing the record type forces a new record set."),
+				},
+			},
+			"fqdn": schema.StringAttribute{
+				Computed:    true,
+				Description: "Fully qualified name of the records in A-label form without the trailing dot",
+				PlanModifiers: []planmodifier.String{
+					// name and domain force replacement, so the value never changes in place
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultRecordTTL),
+				Description: "TTL applied to every record of the set",
+			},
+			"records": schema.SetNestedAttribute{
+				Required:    true,
+				Description: "Values of the record set",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							Optional:    true,
+							Description: "Record content (not used for CAA)",
+						},
+						"priority": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Priority for MX/SRV",
+						},
+						"caa_value": schema.StringAttribute{
+							Optional:    true,
+							Description: "Value for CAA record",
+						},
+						"caa_flags": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Flags for CAA record",
+						},
+						"caa_tag": schema.StringAttribute{
+							Optional:    true,
+							Description: "Tag for CAA record",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *dnsRecordSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(DNSAPI)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("Expected provider.DNSAPI, got %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = client
+}
+
+func (r *dnsRecordSetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var name, domain types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("domain"), &domain)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateNameConfig(name, domain, &resp.Diagnostics)
+}
+
+func (r *dnsRecordSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dnsRecordSetModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, defaultRecordTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.converge(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError("Error creating record set", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(plan.id())
+	plan.FQDN = types.StringValue(fqdn(plan.Name.ValueString(), plan.Domain.ValueString()))
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dnsRecordSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dnsRecordSetModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, defaultRecordTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.current(ctx, &state)
+	if err != nil {
+		if IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading record set", err.Error())
+		return
+	}
+	if len(current) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	domain := state.Domain.ValueString()
+	prior := map[string]dnsRecordSetRecordModel{}
+	for _, m := range state.Records {
+		prior[state.zoneRecord(m).key()] = m
+	}
+
+	records := make([]dnsRecordSetRecordModel, 0, len(current))
+	seen := map[string]bool{}
+	for _, rec := range current {
+		z := zoneRecordFromAPI(rec, domain)
+		if seen[z.key()] {
+			continue
+		}
+		seen[z.key()] = true
+		m, ok := prior[z.key()]
+		if !ok {
+			m = dnsRecordSetRecordModel{
+				Content:  types.StringValue(z.Content),
+				CAAValue: types.StringNull(),
+				CAAFlags: types.Int64Null(),
+				CAATag:   types.StringNull(),
+			}
+			if z.Type == "CAA" {
+				m.Content = types.StringNull()
+				m.CAAValue = types.StringValue(z.CAAValue)
+				m.CAAFlags = types.Int64PointerValue(z.CAAFlags)
+				m.CAATag = types.StringValue(z.CAATag)
+			}
+		}
+		m.Priority = types.Int64PointerValue(z.Priority)
+		records = append(records, m)
+	}
+	// The set has a single TTL. Any record that differs from the prior TTL
+	// is reported as that record's TTL, so the drift is planned regardless of
+	// the order the API lists the records in.
+	if state.TTL.IsNull() || state.TTL.IsUnknown() {
+		state.TTL = types.Int64Value(current[0].TTL)
+	}
+	for _, rec := range current {
+		if rec.TTL != 0 && rec.TTL != state.TTL.ValueInt64() {
+			state.TTL = types.Int64Value(rec.TTL)
+			break
+		}
+	}
+	state.Records = records
+	state.ID = types.StringValue(state.id())
+	state.FQDN = types.StringValue(fqdn(state.Name.ValueString(), state.Domain.ValueString()))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dnsRecordSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan dnsRecordSetModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, defaultRecordTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.converge(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError("Error updating record set", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(plan.id())
+	plan.FQDN = types.StringValue(fqdn(plan.Name.ValueString(), plan.Domain.ValueString()))
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dnsRecordSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dnsRecordSetModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, defaultRecordTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.current(ctx, &state)
+	if err != nil {
+		if IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting record set", err.Error())
+		return
+	}
+	changes := zoneChanges{Delete: current}
+	if err := applyZoneChanges(ctx, r.client, zoneTargetService(state.Domain, state.Service), changes); err != nil {
+		resp.Diagnostics.AddError("Error deleting record set", err.Error())
+	}
+}
+
+func (r *dnsRecordSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Supported formats:
+	//   <domain>:<name>:<type>             e.g. example.com:app:A
+	//   <domain>:<service>:<name>:<type>   e.g. example.com:12345678:@:CAA
+	parts := strings.Split(req.ID, ":")
+	var domain, service, name, rtype string
+	switch len(parts) {
+	case 3:
+		domain, name, rtype = parts[0], parts[1], parts[2]
+	case 4:
+		domain, service, name, rtype = parts[0], parts[1], parts[2], parts[3]
+	default:
+		resp.Diagnostics.AddError("Invalid import format",
+			"Expected one of:\n"+
+				"  <domain>:<name>:<type>\n"+
+				"  <domain>:<service>:<name>:<type>")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
+	if service != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service"), service)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), displayName(name, domain))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), strings.ToUpper(rtype))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// current lists the existing records of the set, matching the name exactly
+// because the API filters by substring.
+func (r *dnsRecordSetResource) current(ctx context.Context, m *dnsRecordSetModel) ([]DNSRecord, error) {
+	domain := m.Domain.ValueString()
+	name := relativeName(m.Name.ValueString(), domain)
+	rtype := strings.ToUpper(m.Type.ValueString())
+	if err := resolveServiceValue(ctx, r.client, m.Domain, &m.Service); err != nil {
+		return nil, err
+	}
+
+	records, err := r.client.ListRecords(ctx, zoneTargetService(m.Domain, m.Service), name, rtype, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var out []DNSRecord
+	for _, rec := range records {
+		if sameName(rec.Name, name, domain) && strings.EqualFold(rec.Type, rtype) {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+// converge creates, updates and deletes individual records so the set matches
+// the plan. Values that did not change are not touched.
+func (r *dnsRecordSetResource) converge(ctx context.Context, plan *dnsRecordSetModel) error {
+	desired := make([]zoneRecord, 0, len(plan.Records))
+	for _, m := range plan.Records {
+		z := plan.zoneRecord(m)
+		if z.Type != "CAA" && z.Content == "" {
+			return fmt.Errorf("the 'content' attribute is required for non-CAA records")
+		}
+		desired = append(desired, z)
+	}
+
+	current, err := r.current(ctx, plan)
+	if err != nil {
+		return err
+	}
+	changes := planZoneChanges(current, desired, plan.Domain.ValueString(), nil, true)
+	return applyZoneChanges(ctx, r.client, zoneTargetService(plan.Domain, plan.Service), changes)
+}
+
+// zoneRecord combines the set-wide name, type and TTL with one value.
+func (m *dnsRecordSetModel) zoneRecord(v dnsRecordSetRecordModel) zoneRecord {
+	z := zoneRecord{
+		Name:     relativeName(m.Name.ValueString(), m.Domain.ValueString()),
+		Type:     strings.ToUpper(m.Type.ValueString()),
+		Content:  v.Content.ValueString(),
+		TTL:      m.TTL.ValueInt64(),
+		Priority: v.Priority.ValueInt64Pointer(),
+	}
+	if z.Type == "CAA" {
+		z.CAAValue = v.CAAValue.ValueString()
+		z.CAAFlags = v.CAAFlags.ValueInt64Pointer()
+		if z.CAAFlags == nil {
+			z.CAAFlags = ptrI(0)
+		}
+		z.CAATag = v.CAATag.ValueString()
+		z.Content = ""
+	}
+	return z
+}
+
+func (m *dnsRecordSetModel) id() string {
+	return strings.Join([]string{
+		m.Domain.ValueString(),
+		zoneTargetService(m.Domain, m.Service),
+		displayName(m.Name.ValueString(), m.Domain.ValueString()),
+		strings.ToUpper(m.Type.ValueString()),
+	}, ":")
+}
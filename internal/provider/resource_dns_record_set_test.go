@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/JoystiC/terraform-provider-active24/internal/fakeapi"
+)
+
+func TestAccDNSRecordSet_basic(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.FQDNNames = true
+	// Same name with another type and a name containing "app" must be left alone
+	srv.Put(testAccService, fakeapi.Record{Name: "app", Type: "TXT", Content: "hello", TTL: 3600})
+	srv.Put(testAccService, fakeapi.Record{Name: "myapp", Type: "A", Content: "10.9.9.9", TTL: 3600})
+
+	ids := map[string]int64{}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordSetConfig("app", "A", 300, `
+    { content = "10.0.0.1" },
+    { content = "10.0.0.2" },
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record_set.test", "id", testAccDomain+":"+testAccService+":app:A"),
+					resource.TestCheckResourceAttr("active24_dns_record_set.test", "records.#", "2"),
+					testAccCheckZone(srv, map[string]bool{
+						"app TXT hello 3600": true, "myapp A 10.9.9.9 3600": true,
+						"app A 10.0.0.1 300": true, "app A 10.0.0.2 300": true,
+					}),
+					testAccRecordSetIDs(srv, ids),
+				),
+			},
+			{
+				// Adding a value creates one record and leaves the others alone
+				Config: testAccProviderConfig(srv) + testAccDNSRecordSetConfig("app", "A", 300, `
+    { content = "10.0.0.1" },
+    { content = "10.0.0.2" },
+    { content = "10.0.0.3" },
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record_set.test", "records.#", "3"),
+					testAccCheckZone(srv, map[string]bool{
+						"app TXT hello 3600": true, "myapp A 10.9.9.9 3600": true,
+						"app A 10.0.0.1 300": true, "app A 10.0.0.2 300": true, "app A 10.0.0.3 300": true,
+					}),
+					testAccRecordSetIDs(srv, ids),
+				),
+			},
+			{
+				// A value added out of band shows up as drift
+				PreConfig: func() {
+					srv.Put(testAccService, fakeapi.Record{Name: "app", Type: "A", Content: "10.0.0.9", TTL: 300})
+				},
+				Config: testAccProviderConfig(srv) + testAccDNSRecordSetConfig("app", "A", 300, `
+    { content = "10.0.0.1" },
+    { content = "10.0.0.2" },
+    { content = "10.0.0.3" },
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// Removing a value and changing the TTL converges the set
+				Config: testAccProviderConfig(srv) + testAccDNSRecordSetConfig("app", "A", 600, `
+    { content = "10.0.0.1" },
+    { content = "10.0.0.3" },
+`),
+				Check: testAccCheckZone(srv, map[string]bool{
+					"app TXT hello 3600": true, "myapp A 10.9.9.9 3600": true,
+					"app A 10.0.0.1 600": true, "app A 10.0.0.3 600": true,
+				}),
+			},
+			{
+				ResourceName:      "active24_dns_record_set.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s:%s:app:A", testAccDomain, testAccService),
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckZone(srv, map[string]bool{
+			"app TXT hello 3600": true, "myapp A 10.9.9.9 3600": true,
+		}),
+	})
+}
+
+func TestAccDNSRecordSet_caa(t *testing.T) {
+	srv := newTestAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordSetConfig("@", "CAA", 3600, `
+    { caa_flags = 0, caa_tag = "issue", caa_value = "letsencrypt.org" },
+    { caa_flags = 0, caa_tag = "iodef", caa_value = "mailto:ssl@example.com" },
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record_set.test", "records.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("active24_dns_record_set.test", "records.*", map[string]string{
+						"caa_tag":   "iodef",
+						"caa_value": "mailto:ssl@example.com",
+					}),
+					testAccCheckZone(srv, map[string]bool{
+						"@ CAA letsencrypt.org 3600": true, "@ CAA mailto:ssl@example.com 3600": true,
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecordSet_lowercaseType(t *testing.T) {
+	srv := newTestAccServer(t)
+	ids := map[string]int64{}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The type is kept as written, so the refresh plans no replacement
+				Config: testAccProviderConfig(srv) + testAccDNSRecordSetConfig("app", "a", 300, `
+    { content = "10.0.0.1" },
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record_set.test", "type", "a"),
+					resource.TestCheckResourceAttr("active24_dns_record_set.test", "id", testAccDomain+":"+testAccService+":app:A"),
+					testAccRecordSetIDs(srv, ids),
+				),
+			},
+			{
+				// Changing only the case does not recreate the records
+				Config: testAccProviderConfig(srv) + testAccDNSRecordSetConfig("app", "A", 300, `
+    { content = "10.0.0.1" },
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record_set.test", "type", "A"),
+					testAccCheckZone(srv, map[string]bool{"app A 10.0.0.1 300": true}),
+					testAccRecordSetIDs(srv, ids),
+				),
+			},
+		},
+	})
+}
+
+// TestAccDNSRecordSet_mixedTTL changes the TTL of the first and then the
+// last record out of band. Both must show as drift whatever the list order.
+func TestAccDNSRecordSet_mixedTTL(t *testing.T) {
+	srv := newTestAccServer(t)
+	config := testAccProviderConfig(srv) + testAccDNSRecordSetConfig("app", "A", 300, `
+    { content = "10.0.0.1" },
+    { content = "10.0.0.2" },
+`)
+	setTTL := func(index int, ttl int64) func() {
+		return func() {
+			rec := srv.Records(testAccService)[index]
+			rec.TTL = ttl
+			srv.Put(testAccService, rec)
+		}
+	}
+	converged := testAccCheckZone(srv, map[string]bool{"app A 10.0.0.1 300": true, "app A 10.0.0.2 300": true})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{Config: config, Check: converged},
+			{
+				PreConfig:          setTTL(0, 600),
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{Config: config, Check: converged},
+			{
+				PreConfig:          setTTL(1, 600),
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{Config: config, Check: converged},
+		},
+	})
+}
+
+func testAccDNSRecordSetConfig(name, rtype string, ttl int, records string) string {
+	return fmt.Sprintf(`
+resource "active24_dns_record_set" "test" {
+  domain  = %q
+  service = %q
+  name    = %q
+  type    = %q
+  ttl     = %d
+  records = [%s]
+}
+`, testAccDomain, testAccService, name, rtype, ttl, records)
+}
+
+// testAccRecordSetIDs fails when a record seen in an earlier step got a new
+// ID, i.e. was recreated instead of left alone.
+func testAccRecordSetIDs(srv *fakeapi.Server, ids map[string]int64) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		for _, rec := range srv.Records(testAccService) {
+			if id, ok := ids[rec.Content]; ok && id != rec.ID {
+				return fmt.Errorf("record %q was recreated: ID %d, previously %d", rec.Content, rec.ID, id)
+			}
+			ids[rec.Content] = rec.ID
+		}
+		return nil
+	}
+}
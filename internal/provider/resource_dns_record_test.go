@@ -0,0 +1,831 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"github.com/JoystiC/terraform-provider-active24/internal/fakeapi"
+)
+
+func TestAccDNSRecord_basic(t *testing.T) {
+	srv := newTestAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordDestroyed(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordConfig("www", "A", "10.0.0.1", 300),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("active24_dns_record.test", "id"),
+					resource.TestCheckResourceAttr("active24_dns_record.test", "name", "www"),
+					resource.TestCheckResourceAttr("active24_dns_record.test", "content", "10.0.0.1"),
+					resource.TestCheckResourceAttr("active24_dns_record.test", "ttl", "300"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordConfig("www", "A", "10.0.0.2", 600),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "content", "10.0.0.2"),
+					resource.TestCheckResourceAttr("active24_dns_record.test", "ttl", "600"),
+				),
+			},
+			{
+				ResourceName:      "active24_dns_record.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s:%s:www:A", testAccDomain, testAccService),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_replace(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.AddService("87654321", testAccDomain)
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordDestroyed(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordConfig("www", "A", "10.0.0.1", 300),
+				Check:  testAccCheckDNSRecordID(&id, true),
+			},
+			{
+				// content and ttl are updated in place
+				Config: testAccProviderConfig(srv) + testAccDNSRecordConfig("www", "A", "10.0.0.2", 600),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("active24_dns_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckDNSRecordID(&id, true),
+			},
+			{
+				// A different case of the same type does not replace the record
+				Config: testAccProviderConfig(srv) + testAccDNSRecordConfig("www", "a", "10.0.0.2", 600),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("active24_dns_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckDNSRecordID(&id, true),
+			},
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordConfig("www", "AAAA", "2001:db8::1", 600),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("active24_dns_record.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDNSRecordID(&id, false),
+					testAccCheckDNSRecordStored(srv, "www", "2001:db8::1"),
+				),
+			},
+			{
+				// Moving to another service creates the record there and
+				// deletes it from the old one
+				Config: testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "active24_dns_record" "test" {
+  domain  = %q
+  service = "87654321"
+  name    = "www"
+  type    = "AAAA"
+  content = "2001:db8::1"
+  ttl     = 600
+}
+`, testAccDomain),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("active24_dns_record.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: func(*terraform.State) error {
+					if n := len(srv.Records(testAccService)); n != 0 {
+						return fmt.Errorf("old service still has %d records", n)
+					}
+					if n := len(srv.Records("87654321")); n != 1 {
+						return fmt.Errorf("new service has %d records, expected 1", n)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_caa(t *testing.T) {
+	srv := newTestAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordDestroyed(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "active24_dns_record" "test" {
+  domain    = %q
+  service   = %q
+  name      = "@"
+  type      = "CAA"
+  caa_flags = 0
+  caa_tag   = "issue"
+  caa_value = "letsencrypt.org"
+  ttl       = 3600
+}
+`, testAccDomain, testAccService),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "caa_value", "letsencrypt.org"),
+					resource.TestCheckNoResourceAttr("active24_dns_record.test", "content"),
+				),
+			},
+			{
+				ResourceName:      "active24_dns_record.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s:%s:@:CAA:letsencrypt.org", testAccDomain, testAccService),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestAccDNSRecord_caaLowercase covers a type written in lower case, which
+// must still send the CAA fields on create and update.
+func TestAccDNSRecord_caaLowercase(t *testing.T) {
+	srv := newTestAccServer(t)
+	config := func(value string) string {
+		return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "active24_dns_record" "test" {
+  domain    = %q
+  service   = %q
+  name      = "@"
+  type      = "caa"
+  caa_flags = 0
+  caa_tag   = "issue"
+  caa_value = %q
+  ttl       = 3600
+}
+`, testAccDomain, testAccService, value)
+	}
+	checkCAA := func(value string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			records := srv.Records(testAccService)
+			if len(records) != 1 {
+				return fmt.Errorf("expected 1 record, got %d", len(records))
+			}
+			rec := records[0]
+			if rec.Type != "CAA" || rec.Tag != "issue" || rec.CAAValue != value || rec.Flags == nil || *rec.Flags != 0 {
+				return fmt.Errorf("unexpected CAA record: type %q, tag %q, value %q, flags %v", rec.Type, rec.Tag, rec.CAAValue, rec.Flags)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordDestroyed(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: config("letsencrypt.org"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "type", "caa"),
+					checkCAA("letsencrypt.org"),
+				),
+			},
+			{
+				Config: config("sectigo.com"),
+				Check:  checkCAA("sectigo.com"),
+			},
+		},
+	})
+}
+
+// TestAccDNSRecord_readBack covers APIs that return no body on create and
+// FQDN names on read, with a record whose name is a substring of another.
+func TestAccDNSRecord_readBack(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.OmitCreateBody = true
+	srv.FQDNNames = true
+	srv.PageSize = 2
+	srv.Put(testAccService, fakeapi.Record{Name: "status-service.dev", Type: "A", Content: "10.0.0.9", TTL: 300})
+	srv.Put(testAccService, fakeapi.Record{Name: "other", Type: "A", Content: "10.0.0.8", TTL: 300})
+	srv.Put(testAccService, fakeapi.Record{Name: "another", Type: "A", Content: "10.0.0.7", TTL: 300})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordDestroyed(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordConfig("service.dev", "A", "10.0.0.9", 300),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "name", "service.dev"),
+					testAccCheckDNSRecordStored(srv, "service.dev", "10.0.0.9"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_transientErrors(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.Fail(fakeapi.Failure{Method: http.MethodPost, Status: http.StatusTooManyRequests, RetryAfter: "0"})
+	srv.Fail(fakeapi.Failure{Method: http.MethodGet, Status: http.StatusServiceUnavailable, Count: 2})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordDestroyed(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordConfig("www", "A", "10.0.0.1", 300),
+				Check:  testAccCheckDNSRecordStored(srv, "www", "10.0.0.1"),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_srv(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.FQDNNames = true
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordDestroyed(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("_xmpp._tcp", "SRV", `
+  content    = "5 5222 xmpp.example.com"
+  srv_target = "xmpp.example.com"
+`),
+				ExpectError: regexp.MustCompile("Conflicting SRV attributes"),
+			},
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("_xmpp._tcp", "SRV", `
+  priority   = 10
+  srv_weight = 5
+  srv_port   = 70000
+  srv_target = "xmpp.example.com"
+`),
+				ExpectError: regexp.MustCompile("Value out of range"),
+			},
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("voip", "SRV", `
+  srv_service  = "_sip"
+  srv_protocol = "tcp"
+  priority     = 10
+  srv_weight   = 5
+  srv_port     = 5060
+  srv_target   = "sip.example.com"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "name", "voip"),
+					resource.TestCheckResourceAttr("active24_dns_record.test", "srv_port", "5060"),
+					resource.TestCheckNoResourceAttr("active24_dns_record.test", "content"),
+					testAccCheckDNSRecordStored(srv, "_sip._tcp.voip", "sip.example.com"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("voip", "SRV", `
+  srv_service  = "_sip"
+  srv_protocol = "tcp"
+  priority     = 10
+  srv_weight   = 5
+  srv_port     = 5061
+  srv_target   = "sip.example.com"
+`),
+				Check: resource.TestCheckResourceAttr("active24_dns_record.test", "srv_port", "5061"),
+			},
+			{
+				ResourceName:            "active24_dns_record.test",
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("%s:%s:_sip._tcp.voip:SRV", testAccDomain, testAccService),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"name", "srv_service", "srv_protocol"},
+			},
+			{
+				// Presentation-form content is split into the API fields
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("_xmpp._tcp", "SRV", `
+  content = "10 5 5222 xmpp.example.com"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "content", "10 5 5222 xmpp.example.com"),
+					resource.TestCheckNoResourceAttr("active24_dns_record.test", "priority"),
+					testAccCheckDNSRecordStored(srv, "_xmpp._tcp", "xmpp.example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_tlsaSSHFP(t *testing.T) {
+	srv := newTestAccServer(t)
+	certData := "8CB0FC6C527506A053F4F14C8464BEBBD6DEDE2738D11468DD953D7D6A3021F1"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordDestroyed(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("_443._tcp.www", "TLSA", `
+  tlsa_usage         = 3
+  tlsa_selector      = 1
+  tlsa_matching_type = 1
+  tlsa_cert_data     = "abc"
+`),
+				ExpectError: regexp.MustCompile("even number of hexadecimal digits"),
+			},
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("_443._tcp.www", "TLSA", fmt.Sprintf(`
+  tlsa_usage         = 3
+  tlsa_selector      = 1
+  tlsa_matching_type = 1
+  tlsa_cert_data     = %q
+`, certData)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "tlsa_cert_data", certData),
+					resource.TestCheckNoResourceAttr("active24_dns_record.test", "content"),
+					testAccCheckDNSRecordStored(srv, "_443._tcp.www", "3 1 1 "+certData),
+				),
+			},
+			{
+				// The API returning lower-case hex is not drift
+				PreConfig: func() {
+					for _, rec := range srv.Records(testAccService) {
+						rec.Content = strings.ToLower(rec.Content)
+						srv.Put(testAccService, rec)
+					}
+				},
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("_443._tcp.www", "TLSA", fmt.Sprintf(`
+  tlsa_usage         = 3
+  tlsa_selector      = 1
+  tlsa_matching_type = 1
+  tlsa_cert_data     = %q
+`, certData)),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            "active24_dns_record.test",
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("%s:%s:_443._tcp.www:TLSA", testAccDomain, testAccService),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"tlsa_cert_data"},
+			},
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("host", "SSHFP", `
+  sshfp_algorithm        = 4
+  sshfp_fingerprint_type = 2
+  sshfp_fingerprint      = "abc123"
+`),
+				ExpectError: regexp.MustCompile("must be 64 hexadecimal digits"),
+			},
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("host", "SSHFP", `
+  content = "4 2 2f9a4f6d5a0c6b1e0f1e2d3c4b5a69788796a5b4c3d2e1f00112233445566778"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "content", "4 2 2f9a4f6d5a0c6b1e0f1e2d3c4b5a69788796a5b4c3d2e1f00112233445566778"),
+					resource.TestCheckNoResourceAttr("active24_dns_record.test", "sshfp_fingerprint"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_equivalentContent(t *testing.T) {
+	srv := newTestAccServer(t)
+	config := testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "active24_dns_record" "cname" {
+  domain  = %[1]q
+  service = %[2]q
+  name    = "blog"
+  type    = "CNAME"
+  content = "Example.GitHub.io"
+}
+
+resource "active24_dns_record" "aaaa" {
+  domain  = %[1]q
+  service = %[2]q
+  name    = "www"
+  type    = "AAAA"
+  content = "2001:0db8:0000:0000:0000:0000:0000:0001"
+}
+
+resource "active24_dns_record" "txt" {
+  domain  = %[1]q
+  service = %[2]q
+  name    = "@"
+  type    = "TXT"
+  content = "v=spf1 -all"
+}
+`, testAccDomain, testAccService)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordDestroyed(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				// The API returning another spelling of the same values is not drift
+				PreConfig: func() {
+					canonical := map[string]string{
+						"Example.GitHub.io":                       "example.github.io.",
+						"2001:0db8:0000:0000:0000:0000:0000:0001": "2001:db8::1",
+						"v=spf1 -all":                             `"v=spf1 -all"`,
+					}
+					for _, rec := range srv.Records(testAccService) {
+						rec.Content = canonical[rec.Content]
+						srv.Put(testAccService, rec)
+					}
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.cname", "content", "Example.GitHub.io"),
+					resource.TestCheckResourceAttr("active24_dns_record.aaaa", "content", "2001:0db8:0000:0000:0000:0000:0000:0001"),
+					resource.TestCheckResourceAttr("active24_dns_record.txt", "content", "v=spf1 -all"),
+				),
+			},
+			{
+				// A real change is still detected
+				PreConfig: func() {
+					for _, rec := range srv.Records(testAccService) {
+						if rec.Type == "AAAA" {
+							rec.Content = "2001:db8::2"
+							srv.Put(testAccService, rec)
+						}
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_longTXT(t *testing.T) {
+	srv := newTestAccServer(t)
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA", 10)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordDestroyed(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("mail._domainkey", "TXT", fmt.Sprintf("  content = %q\n", dkim)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "content", dkim),
+					testAccCheckDNSRecordStored(srv, "mail._domainkey", fmt.Sprintf(`"%s" "%s"`, dkim[:255], dkim[255:])),
+				),
+			},
+			{
+				ResourceName:      "active24_dns_record.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s:%s:mail._domainkey:TXT", testAccDomain, testAccService),
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("mail._domainkey", "TXT", fmt.Sprintf("  txt_strings = [%q]\n", dkim)),
+				ExpectError: regexp.MustCompile("TXT string too long"),
+			},
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("mail._domainkey", "TXT", fmt.Sprintf("  txt_strings = [%q, %q, %q]\n", dkim[:200], dkim[200:400], dkim[400:])),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "txt_strings.#", "3"),
+					resource.TestCheckNoResourceAttr("active24_dns_record.test", "content"),
+					testAccCheckDNSRecordStored(srv, "mail._domainkey", fmt.Sprintf(`"%s" "%s" "%s"`, dkim[:200], dkim[200:400], dkim[400:])),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_serviceDiscovery(t *testing.T) {
+	srv := newTestAccServer(t)
+	config := func(domain string) string {
+		return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "active24_dns_record" "test" {
+  domain  = %q
+  name    = "www"
+  type    = "A"
+  content = "10.0.0.1"
+}
+
+data "active24_dns_record" "test" {
+  domain     = %[1]q
+  name       = "www"
+  type       = "A"
+  depends_on = [active24_dns_record.test]
+}
+`, domain)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordDestroyed(srv),
+		Steps: []resource.TestStep{
+			{
+				Config:      config("example.org"),
+				ExpectError: regexp.MustCompile(`no DNS or domain service for "example.org"`),
+			},
+			{
+				// An empty service would be replaced by the resolved ID
+				Config: testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "active24_dns_record" "test" {
+  domain  = %q
+  service = ""
+  name    = "www"
+  type    = "A"
+  content = "10.0.0.1"
+}
+`, testAccDomain),
+				ExpectError: regexp.MustCompile("`service` must not be empty"),
+			},
+			{
+				Config: config(testAccDomain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "service", testAccService),
+					resource.TestCheckResourceAttr("data.active24_dns_record.test", "service", testAccService),
+					testAccCheckDNSRecordStored(srv, "www", "10.0.0.1"),
+				),
+			},
+			{
+				Config: strings.Replace(config(testAccDomain), `"10.0.0.1"`, `"10.0.0.2"`, 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("active24_dns_record.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("active24_dns_record.test", tfjsonpath.New("service"), knownvalue.StringExact(testAccService)),
+					},
+				},
+				Check: testAccCheckDNSRecordStored(srv, "www", "10.0.0.2"),
+			},
+			{
+				ResourceName:      "active24_dns_record.test",
+				ImportState:       true,
+				ImportStateId:     testAccDomain + ":www:A",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_fqdnName(t *testing.T) {
+	srv := newTestAccServer(t)
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordDestroyed(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordConfig("www.example.com", "A", "10.0.0.1", 300),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDNSRecordStored(srv, "www", "10.0.0.1"),
+					testAccCheckDNSRecordID(&id, true),
+					resource.TestCheckResourceAttr("active24_dns_record.test", "name", "www.example.com"),
+					resource.TestCheckResourceAttr("active24_dns_record.test", "fqdn", "www.example.com"),
+				),
+			},
+			{
+				// Every spelling of the same name is the same record
+				Config: testAccProviderConfig(srv) + testAccDNSRecordConfig("www.example.com.", "A", "10.0.0.1", 300),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("active24_dns_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDNSRecordStored(srv, "www", "10.0.0.1"),
+					testAccCheckDNSRecordID(&id, true),
+					resource.TestCheckResourceAttr("active24_dns_record.test", "fqdn", "www.example.com"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordConfig("@", "A", "10.0.0.1", 300),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDNSRecordStored(srv, "", "10.0.0.1"),
+					resource.TestCheckResourceAttr("active24_dns_record.test", "fqdn", "example.com"),
+				),
+			},
+			{
+				// Import by ID has no configured name, the apex is read as "@"
+				ResourceName: "active24_dns_record.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fmt.Sprintf("%s:%s:%s", testAccDomain, testAccService, s.RootModule().Resources["active24_dns_record.test"].Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSRecordTypedConfig("sip", "SRV", `
+  srv_service  = "sip"
+  srv_protocol = "tcp"
+  priority     = 10
+  srv_weight   = 5
+  srv_port     = 5060
+  srv_target   = "pbx.example.com"
+`),
+				Check: resource.TestCheckResourceAttr("active24_dns_record.test", "fqdn", "_sip._tcp.sip.example.com"),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_idn(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.AddService("87654321", "xn--pklad-zsa96e.cz")
+	config := testAccProviderConfig(srv) + `
+resource "active24_dns_record" "test" {
+  domain  = "příklad.cz"
+  service = "87654321"
+  name    = "poštovní"
+  type    = "CNAME"
+  content = "mail.příklad.cz"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "name", "poštovní"),
+					resource.TestCheckResourceAttr("active24_dns_record.test", "name_unicode", "poštovní"),
+					resource.TestCheckResourceAttr("active24_dns_record.test", "content", "mail.příklad.cz"),
+					func(*terraform.State) error {
+						recs := srv.Records("87654321")
+						if len(recs) != 1 || recs[0].Name != "xn--potovn-8va73g" || recs[0].Content != "mail.xn--pklad-zsa96e.cz" {
+							return fmt.Errorf("expected A-labels in the API, got %+v", recs)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// The punycode spelling describes the same record
+				Config: strings.ReplaceAll(config, `"poštovní"`, `"xn--potovn-8va73g"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("active24_dns_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_record.test", "name", "xn--potovn-8va73g"),
+					resource.TestCheckResourceAttr("active24_dns_record.test", "name_unicode", "poštovní"),
+				),
+			},
+			{
+				Config: config,
+			},
+			{
+				ResourceName:      "active24_dns_record.test",
+				ImportState:       true,
+				ImportStateId:     "příklad.cz:87654321:poštovní:CNAME",
+				ImportStateVerify: true,
+				// Imported content is the A-label spelling returned by the API
+				ImportStateVerifyIgnore: []string{"content"},
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_validation(t *testing.T) {
+	srv := newTestAccServer(t)
+
+	cases := []struct {
+		name, rtype string
+		attrs       []string
+		err         string
+	}{
+		{"www", "A", []string{`content = "10.0.0.300"`}, "Invalid IPv4 address"},
+		{"www", "A", []string{`content = "2001:db8::1"`}, "Invalid IPv4 address"},
+		{"www", "AAAA", []string{`content = "10.0.0.1"`}, "Invalid IPv6 address"},
+		{"www", "A", nil, "Missing content"},
+		{"www", "A", []string{`content = "10.0.0.1"`, `priority = 10`}, "`priority` can only be used with type MX or SRV"},
+		{"www", "TXT", []string{`content = "x"`, `caa_tag = "issue"`}, "`caa_tag` can only be used with type CAA"},
+		{"@", "CNAME", []string{`content = "example.net"`}, "CNAME at zone apex"},
+		{"example.com", "CNAME", []string{`content = "example.net"`}, "CNAME at zone apex"},
+		{"@", "CAA", []string{`caa_tag = "issues"`, `caa_value = "letsencrypt.org"`}, "Invalid CAA tag"},
+		{"www", "A", []string{`content = "10.0.0.1"`, `ttl = 30`}, "`ttl` must be between 60 and 604800"},
+		{"www", "SOA", []string{`content = "x"`}, "Unsupported record type"},
+	}
+	steps := make([]resource.TestStep, 0, len(cases))
+	for _, c := range cases {
+		var attrs string
+		for _, a := range c.attrs {
+			attrs += "  " + a + "\n"
+		}
+		steps = append(steps, resource.TestStep{
+			Config:      testAccProviderConfig(srv) + testAccDNSRecordTypedConfig(c.name, c.rtype, attrs),
+			ExpectError: regexp.MustCompile(regexp.QuoteMeta(c.err)),
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps:                    steps,
+	})
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("invalid configurations reached the API with %d requests", n)
+	}
+}
+
+// testAccDNSRecordTypedConfig renders a record whose value is given by attrs.
+func testAccDNSRecordTypedConfig(name, rtype, attrs string) string {
+	return fmt.Sprintf(`
+resource "active24_dns_record" "test" {
+  domain  = %q
+  service = %q
+  name    = %q
+  type    = %q
+%s}
+`, testAccDomain, testAccService, name, rtype, attrs)
+}
+
+func testAccDNSRecordConfig(name, rtype, content string, ttl int) string {
+	return fmt.Sprintf(`
+resource "active24_dns_record" "test" {
+  domain  = %q
+  service = %q
+  name    = %q
+  type    = %q
+  content = %q
+  ttl     = %d
+}
+`, testAccDomain, testAccService, name, rtype, content, ttl)
+}
+
+// testAccCheckDNSRecordStored verifies the state ID points at the expected record in the fake API.
+func testAccCheckDNSRecordStored(srv *fakeapi.Server, name, content string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["active24_dns_record.test"]
+		if !ok {
+			return fmt.Errorf("active24_dns_record.test not found in state")
+		}
+		for _, rec := range srv.Records(testAccService) {
+			if fmt.Sprintf("%d", rec.ID) == rs.Primary.ID {
+				if rec.Name != name || rec.Content != content {
+					return fmt.Errorf("state ID %s points at %s %s, expected %s %s", rs.Primary.ID, rec.Name, rec.Content, name, content)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("record %s not found in API", rs.Primary.ID)
+	}
+}
+
+// testAccCheckDNSRecordID records the state ID in *id and checks whether it
+// is the same as in the previous call.
+func testAccCheckDNSRecordID(id *string, same bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["active24_dns_record.test"]
+		if !ok {
+			return fmt.Errorf("active24_dns_record.test not found in state")
+		}
+		prev := *id
+		*id = rs.Primary.ID
+		if prev == "" {
+			return nil
+		}
+		if same && prev != *id {
+			return fmt.Errorf("record was replaced: ID %s, previously %s", *id, prev)
+		}
+		if !same && prev == *id {
+			return fmt.Errorf("record was not replaced: ID %s", *id)
+		}
+		return nil
+	}
+}
+
+func testAccCheckDNSRecordDestroyed(srv *fakeapi.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "active24_dns_record" {
+				continue
+			}
+			for _, rec := range srv.Records(testAccService) {
+				if fmt.Sprintf("%d", rec.ID) == rs.Primary.ID {
+					return fmt.Errorf("record %s still exists", rs.Primary.ID)
+				}
+			}
+		}
+		return nil
+	}
+}
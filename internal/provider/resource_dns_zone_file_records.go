@@ -0,0 +1,456 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure resource implementation
+var _ resource.Resource = &dnsZoneFileRecordsResource{}
+var _ resource.ResourceWithImportState = &dnsZoneFileRecordsResource{}
+var _ resource.ResourceWithValidateConfig = &dnsZoneFileRecordsResource{}
+var _ resource.ResourceWithModifyPlan = &dnsZoneFileRecordsResource{}
+
+func NewDNSZoneFileRecordsResource() resource.Resource {
+	return &dnsZoneFileRecordsResource{}
+}
+
+// dnsZoneFileRecordsResource authoritatively manages the records of a zone
+// from RFC 1035 master file text. It reconciles like
+// active24_dns_zone_records; the parsed records are exposed as a computed map
+// so the plan lists every added, changed and removed record.
+type dnsZoneFileRecordsResource struct {
+	client DNSAPI
+}
+
+type dnsZoneFileRecordsModel struct {
+	ID              types.String `tfsdk:"id"`
+	Service         types.String `tfsdk:"service"`
+	Domain          types.String `tfsdk:"domain"`
+	Content         types.String `tfsdk:"content"`
+	DeleteUnmanaged types.Bool   `tfsdk:"delete_unmanaged"`
+	Records         types.Map    `tfsdk:"records"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// zoneRecordObjectType is the element type of the records map, matching
+// dnsZoneRecordModel.
+var zoneRecordObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":      types.StringType,
+	"type":      types.StringType,
+	"content":   types.StringType,
+	"ttl":       types.Int64Type,
+	"priority":  types.Int64Type,
+	"caa_value": types.StringType,
+	"caa_flags": types.Int64Type,
+	"caa_tag":   types.StringType,
+}}
+
+func (r *dnsZoneFileRecordsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_file_records"
+}
+
+func (r *dnsZoneFileRecordsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Authoritatively manages the records of a zone from an RFC 1035 master file (BIND zone file). Records not in the file are deleted (or only reported, see `delete_unmanaged`).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:    true,
+				Description: "Domain name owning the records (zone), the initial $ORIGIN of the zone file",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Active24 v2 service ID. Resolved from the account's service list by domain when not set",
+				PlanModifiers: servicePlanModifiers(),
+				Validators:    serviceValidators(),
+			},
+			"content": schema.StringAttribute{
+				Required:    true,
+				Description: "Zone file text. SOA and apex NS records are managed by Active24 and ignored.",
+			},
+			"delete_unmanaged": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Delete records that exist in the zone but are not in the zone file. When false, such records are left alone and reported as warnings. Defaults to true.",
+			},
+			"records": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "Records parsed from the zone file, keyed by name, type and value",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name":      schema.StringAttribute{Computed: true, Description: "Record name relative to the zone, `@` for the apex"},
+						"type":      schema.StringAttribute{Computed: true},
+						"content":   schema.StringAttribute{Computed: true, Description: "Record content (null for CAA)"},
+						"ttl":       schema.Int64Attribute{Computed: true},
+						"priority":  schema.Int64Attribute{Computed: true, Description: "Priority for MX/SRV"},
+						"caa_value": schema.StringAttribute{Computed: true},
+						"caa_flags": schema.Int64Attribute{Computed: true},
+						"caa_tag":   schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *dnsZoneFileRecordsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(DNSAPI)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("Expected provider.DNSAPI, got %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = client
+}
+
+func (r *dnsZoneFileRecordsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var content, domain types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content"), &content)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("domain"), &domain)...)
+	if resp.Diagnostics.HasError() || content.IsNull() || content.IsUnknown() || domain.IsUnknown() {
+		return
+	}
+	if _, err := parseZoneFile(content.ValueString(), domain.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid zone file", err.Error())
+	}
+}
+
+// ModifyPlan parses the zone file into the planned records, so the plan shows
+// the records to be added, changed and removed.
+func (r *dnsZoneFileRecordsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var content, domain types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("content"), &content)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("domain"), &domain)...)
+	if resp.Diagnostics.HasError() || content.IsUnknown() || domain.IsUnknown() {
+		return
+	}
+	desired, err := parseZoneFile(content.ValueString(), domain.ValueString())
+	if err != nil {
+		// Reported by ValidateConfig
+		return
+	}
+
+	var prior types.Map
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("records"), &prior)...)
+	}
+	records, diags := zoneFileRecordsValue(ctx, desired, prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("records"), records)...)
+}
+
+func (r *dnsZoneFileRecordsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dnsZoneFileRecordsModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, defaultZoneTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed := r.converge(ctx, &plan, types.MapNull(zoneRecordObjectType), nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setManagedKeys(ctx, resp.Private, managed)...)
+
+	plan.ID = types.StringValue(zoneTargetService(plan.Domain, plan.Service))
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dnsZoneFileRecordsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dnsZoneFileRecordsModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, defaultZoneTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := state.Domain.ValueString()
+	if !resolveServiceAttr(ctx, r.client, state.Domain, &state.Service, &resp.Diagnostics) {
+		return
+	}
+	targetService := zoneTargetService(state.Domain, state.Service)
+	if state.DeleteUnmanaged.IsNull() {
+		// Freshly imported
+		state.DeleteUnmanaged = types.BoolValue(true)
+	}
+
+	current, err := r.client.ListRecords(ctx, targetService, "", "", "", nil)
+	if err != nil {
+		if IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading zone records", err.Error())
+		return
+	}
+
+	prior, d := zoneFileRecordModels(ctx, state.Records)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records := map[string]dnsZoneRecordModel{}
+	var zone []zoneRecord
+	var unmanaged []string
+	for _, rec := range current {
+		if isZoneSystemRecord(rec, domain) {
+			continue
+		}
+		z := zoneRecordFromAPI(rec, domain)
+		k := zoneFileKey(z)
+		if _, seen := records[k]; seen {
+			continue
+		}
+		m, ok := prior[k]
+		if !ok && !state.DeleteUnmanaged.ValueBool() {
+			unmanaged = append(unmanaged, describeZoneRecord(z))
+			continue
+		}
+		records[k] = zoneRecordModelFromAPI(z, m, ok)
+		zone = append(zone, z)
+	}
+	addUnmanagedWarning(&resp.Diagnostics, domain, unmanaged)
+
+	if state.Content.IsNull() {
+		// Imported: start from the zone as it is
+		state.Content = types.StringValue(renderZoneFile(domain, zone))
+	}
+	state.ID = types.StringValue(targetService)
+	state.Records, d = types.MapValueFrom(ctx, zoneRecordObjectType, records)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dnsZoneFileRecordsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state dnsZoneFileRecordsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, defaultZoneTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed, d := getManagedKeys(ctx, req.Private)
+	resp.Diagnostics.Append(d...)
+	if managed == nil {
+		managed, d = zoneFileRecordKeys(ctx, state.Domain.ValueString(), state.Records)
+		resp.Diagnostics.Append(d...)
+	}
+	if plan.Service.IsUnknown() && plan.Domain.Equal(state.Domain) {
+		plan.Service = state.Service
+	}
+
+	managed = r.converge(ctx, &plan, state.Records, managed, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setManagedKeys(ctx, resp.Private, managed)...)
+
+	plan.ID = types.StringValue(zoneTargetService(plan.Domain, plan.Service))
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dnsZoneFileRecordsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dnsZoneFileRecordsModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, defaultZoneTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the records of the zone file are removed, never the whole zone
+	domain := state.Domain.ValueString()
+	managed, d := getManagedKeys(ctx, req.Private)
+	resp.Diagnostics.Append(d...)
+	if managed == nil {
+		managed, d = zoneFileRecordKeys(ctx, domain, state.Records)
+		resp.Diagnostics.Append(d...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !resolveServiceAttr(ctx, r.client, state.Domain, &state.Service, &resp.Diagnostics) {
+		return
+	}
+	targetService := zoneTargetService(state.Domain, state.Service)
+	current, err := r.client.ListRecords(ctx, targetService, "", "", "", nil)
+	if err != nil {
+		if IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Error reading zone records", err.Error())
+		return
+	}
+	changes := planZoneChanges(current, nil, domain, managed, false)
+	if err := applyZoneChanges(ctx, r.client, targetService, changes); err != nil {
+		resp.Diagnostics.AddError("Error deleting zone records", err.Error())
+	}
+}
+
+func (r *dnsZoneFileRecordsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Supported formats:
+	//   <domain>             e.g. example.com
+	//   <domain>:<service>   e.g. example.com:12345678
+	parts := strings.Split(req.ID, ":")
+	if len(parts) > 2 || parts[0] == "" {
+		resp.Diagnostics.AddError("Invalid import format", "Expected <domain> or <domain>:<service>")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[len(parts)-1])...)
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service"), parts[1])...)
+	}
+}
+
+// converge applies the zone file of plan to the zone and sets plan.Records.
+// prior holds the records in state and managed the keys of the records owned
+// by the resource before this apply. It returns the keys owned afterwards.
+func (r *dnsZoneFileRecordsResource) converge(ctx context.Context, plan *dnsZoneFileRecordsModel, prior types.Map, managed map[string]bool, diags *diag.Diagnostics) map[string]bool {
+	domain := plan.Domain.ValueString()
+	desired, err := parseZoneFile(plan.Content.ValueString(), domain)
+	if err != nil {
+		diags.AddAttributeError(path.Root("content"), "Invalid zone file", err.Error())
+		return nil
+	}
+	if !resolveServiceAttr(ctx, r.client, plan.Domain, &plan.Service, diags) {
+		return nil
+	}
+	targetService := zoneTargetService(plan.Domain, plan.Service)
+
+	current, err := r.client.ListRecords(ctx, targetService, "", "", "", nil)
+	if err != nil {
+		diags.AddError("Error reading zone records", err.Error())
+		return nil
+	}
+	changes := planZoneChanges(current, desired, domain, managed, plan.DeleteUnmanaged.ValueBool())
+	if err := applyZoneChanges(ctx, r.client, targetService, changes); err != nil {
+		diags.AddError("Error applying zone records", err.Error())
+		return nil
+	}
+	var unmanaged []string
+	for _, rec := range changes.Unmanaged {
+		unmanaged = append(unmanaged, describeZoneRecord(zoneRecordFromAPI(rec, domain)))
+	}
+	addUnmanagedWarning(diags, domain, unmanaged)
+
+	records, d := zoneFileRecordsValue(ctx, desired, prior)
+	diags.Append(d...)
+	plan.Records = records
+
+	keys := make(map[string]bool, len(desired))
+	for _, z := range desired {
+		keys[z.key()] = true
+	}
+	return keys
+}
+
+// zoneFileKey is the key of a record in the records map: owner, type and
+// value in presentation form. TTL and priority are not part of it, so
+// changing them shows as an in-place change of that record.
+func zoneFileKey(z zoneRecord) string {
+	rtype := strings.ToUpper(z.Type)
+	value := normalizeContent(rtype, z.Content)
+	switch rtype {
+	case "TXT":
+		value = zoneQuote(value)
+	case "CAA":
+		var flags int64
+		if z.CAAFlags != nil {
+			flags = *z.CAAFlags
+		}
+		value = fmt.Sprintf("%d %s %s", flags, strings.ToLower(z.CAATag), zoneQuote(normalizeContent(rtype, z.CAAValue)))
+	}
+	return denormalizeNameFromAPI(strings.ToLower(z.Name)) + " " + rtype + " " + value
+}
+
+// zoneFileRecordsValue builds the records map for desired. Records already in
+// prior keep their element, so the spelling read back from the API does not
+// show as a change; TTL and priority are taken from desired.
+func zoneFileRecordsValue(ctx context.Context, desired []zoneRecord, prior types.Map) (types.Map, diag.Diagnostics) {
+	priorModels, diags := zoneFileRecordModels(ctx, prior)
+	if diags.HasError() {
+		return types.MapUnknown(zoneRecordObjectType), diags
+	}
+	records := make(map[string]dnsZoneRecordModel, len(desired))
+	for _, z := range desired {
+		k := zoneFileKey(z)
+		if _, dup := records[k]; dup {
+			continue
+		}
+		m, ok := priorModels[k]
+		records[k] = zoneRecordModelFromAPI(z, m, ok)
+	}
+	v, d := types.MapValueFrom(ctx, zoneRecordObjectType, records)
+	diags.Append(d...)
+	return v, diags
+}
+
+func zoneFileRecordModels(ctx context.Context, records types.Map) (map[string]dnsZoneRecordModel, diag.Diagnostics) {
+	out := map[string]dnsZoneRecordModel{}
+	if records.IsNull() || records.IsUnknown() {
+		return out, nil
+	}
+	diags := records.ElementsAs(ctx, &out, false)
+	return out, diags
+}
+
+func zoneFileRecordKeys(ctx context.Context, domain string, records types.Map) (map[string]bool, diag.Diagnostics) {
+	models, diags := zoneFileRecordModels(ctx, records)
+	keys := make(map[string]bool, len(models))
+	for _, m := range models {
+		keys[m.toZoneRecord(domain).key()] = true
+	}
+	return keys, diags
+}
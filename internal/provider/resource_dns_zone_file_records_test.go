@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"github.com/JoystiC/terraform-provider-active24/internal/fakeapi"
+)
+
+func TestAccDNSZoneFileRecords_basic(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.FQDNNames = true
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "SOA", Content: "ns1.active24.cz. hostmaster.active24.cz. 1 3600 900 604800 3600", TTL: 3600})
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "NS", Content: "ns1.active24.cz", TTL: 3600})
+	srv.Put(testAccService, fakeapi.Record{Name: "stale", Type: "A", Content: "10.9.9.9", TTL: 300})
+
+	zone := `$ORIGIN example.com.
+$TTL 3600
+@         IN SOA ns1.active24.cz. hostmaster.active24.cz. ( 1 3600 900 604800 3600 )
+          IN NS  ns1.active24.cz.
+          IN MX  10 mail
+www   300 IN A   10.0.0.1
+_sip._tcp IN SRV 10 5 5060 sip
+@         IN TXT ( "v=spf1 include:\"spf.example.net\" "
+                   "-all" )
+@         IN CAA 0 issue "letsencrypt.org"
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProviderConfig(srv) + testAccDNSZoneFileRecordsConfig("www A ( 10.0.0.1\n"),
+				ExpectError: regexp.MustCompile(`line 1: unbalanced "\("`),
+			},
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSZoneFileRecordsConfig(zone),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("active24_dns_zone_file_records.test",
+							tfjsonpath.New("records").AtMapKey("www A 10.0.0.1").AtMapKey("ttl"), knownvalue.Int64Exact(300)),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_zone_file_records.test", "records.%", "5"),
+					resource.TestCheckResourceAttr("active24_dns_zone_file_records.test", "records.@ MX mail.example.com.priority", "10"),
+					testAccCheckZone(srv, map[string]bool{
+						"@ SOA": true, "@ NS": true, "www A 10.0.0.1 300": true,
+						"@ MX mail.example.com 3600": true, "@ CAA letsencrypt.org 3600": true,
+						"_sip._tcp SRV sip.example.com 3600":                      true,
+						`@ TXT "v=spf1 include:\"spf.example.net\" " "-all" 3600`: true,
+					}),
+				),
+			},
+			{
+				// A record added out of band is planned for removal
+				PreConfig: func() {
+					srv.Put(testAccService, fakeapi.Record{Name: "manual", Type: "TXT", Content: "hello", TTL: 3600})
+				},
+				Config:             testAccProviderConfig(srv) + testAccDNSZoneFileRecordsConfig(zone),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// A TTL change is an in-place change of that record. Without
+				// $TTL the CAA record inherits the TTL of the line before.
+				Config: testAccProviderConfig(srv) + testAccDNSZoneFileRecordsConfig(`www 900 A 10.0.0.1
+@ CAA 0 issue "letsencrypt.org"
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("active24_dns_zone_file_records.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("active24_dns_zone_file_records.test",
+							tfjsonpath.New("records").AtMapKey("www A 10.0.0.1").AtMapKey("ttl"), knownvalue.Int64Exact(900)),
+					},
+				},
+				Check: testAccCheckZone(srv, map[string]bool{
+					"@ SOA": true, "@ NS": true, "www A 10.0.0.1 900": true,
+					"@ CAA letsencrypt.org 900": true,
+				}),
+			},
+			{
+				ResourceName:            "active24_dns_zone_file_records.test",
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("%s:%s", testAccDomain, testAccService),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"content"},
+			},
+		},
+	})
+}
+
+func testAccDNSZoneFileRecordsConfig(zone string) string {
+	return fmt.Sprintf(`
+resource "active24_dns_zone_file_records" "test" {
+  domain  = %q
+  service = %q
+  content = <<-EOT
+%sEOT
+}
+`, testAccDomain, testAccService, zone)
+}
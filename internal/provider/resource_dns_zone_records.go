@@ -0,0 +1,489 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure resource implementation
+var _ resource.Resource = &dnsZoneRecordsResource{}
+var _ resource.ResourceWithImportState = &dnsZoneRecordsResource{}
+
+func NewDNSZoneRecordsResource() resource.Resource {
+	return &dnsZoneRecordsResource{}
+}
+
+// dnsZoneRecordsResource authoritatively manages every record of a zone
+// (except SOA and apex NS, which belong to Active24).
+type dnsZoneRecordsResource struct {
+	client DNSAPI
+}
+
+type dnsZoneRecordsModel struct {
+	ID              types.String         `tfsdk:"id"`
+	Service         types.String         `tfsdk:"service"`
+	Domain          types.String         `tfsdk:"domain"`
+	DeleteUnmanaged types.Bool           `tfsdk:"delete_unmanaged"`
+	Records         []dnsZoneRecordModel `tfsdk:"records"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type dnsZoneRecordModel struct {
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+	CAAValue types.String `tfsdk:"caa_value"`
+	CAAFlags types.Int64  `tfsdk:"caa_flags"`
+	CAATag   types.String `tfsdk:"caa_tag"`
+}
+
+func (r *dnsZoneRecordsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_records"
+}
+
+func (r *dnsZoneRecordsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Authoritatively manages the full record set of a zone. Records not listed in `records` are deleted (or only reported, see `delete_unmanaged`).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:    true,
+				Description: "Domain name owning the records (zone)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Active24 v2 service ID. Resolved from the account's service list by domain when not set",
+				PlanModifiers: servicePlanModifiers(),
+				Validators:    serviceValidators(),
+			},
+			"delete_unmanaged": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Delete records that exist in the zone but are not listed in `records`. When false, such records are left alone and reported as warnings. Defaults to true.",
+			},
+			"records": schema.SetNestedAttribute{
+				Required:    true,
+				Description: "Desired records of the zone. SOA and apex NS records are managed by Active24 and must not be listed.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Record name relative to the zone, `@` for the apex",
+						},
+						"type": schema.StringAttribute{
+							Required:    true,
+							Description: "DNS record type",
+						},
+						"content": schema.StringAttribute{
+							Optional:    true,
+							Description: "Record content (not used for CAA)",
+						},
+						"ttl": schema.Int64Attribute{
+							Optional:    true,
+							Description: "TTL in seconds. Defaults to 3600.",
+						},
+						"priority": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Priority for MX/SRV",
+						},
+						"caa_value": schema.StringAttribute{
+							Optional:    true,
+							Description: "Value for CAA record",
+						},
+						"caa_flags": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Flags for CAA record",
+						},
+						"caa_tag": schema.StringAttribute{
+							Optional:    true,
+							Description: "Tag for CAA record",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *dnsZoneRecordsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(DNSAPI)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("Expected provider.DNSAPI, got %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = client
+}
+
+func (r *dnsZoneRecordsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dnsZoneRecordsModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Create, defaultZoneTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.converge(ctx, &plan, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setManagedKeys(ctx, resp.Private, zoneRecordKeys(plan.Domain.ValueString(), plan.Records))...)
+
+	plan.ID = types.StringValue(zoneTargetService(plan.Domain, plan.Service))
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dnsZoneRecordsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dnsZoneRecordsModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Read, defaultZoneTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := state.Domain.ValueString()
+	if !resolveServiceAttr(ctx, r.client, state.Domain, &state.Service, &resp.Diagnostics) {
+		return
+	}
+	targetService := zoneTargetService(state.Domain, state.Service)
+	if state.DeleteUnmanaged.IsNull() {
+		// Freshly imported
+		state.DeleteUnmanaged = types.BoolValue(true)
+	}
+
+	current, err := r.client.ListRecords(ctx, targetService, "", "", "", nil)
+	if err != nil {
+		if IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading zone records", err.Error())
+		return
+	}
+
+	prior := map[string]dnsZoneRecordModel{}
+	for _, m := range state.Records {
+		prior[m.toZoneRecord(domain).key()] = m
+	}
+
+	records := make([]dnsZoneRecordModel, 0, len(current))
+	var unmanaged []string
+	seen := map[string]bool{}
+	for _, rec := range current {
+		if isZoneSystemRecord(rec, domain) {
+			continue
+		}
+		z := zoneRecordFromAPI(rec, domain)
+		k := z.key()
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		m, ok := prior[k]
+		if !ok && !state.DeleteUnmanaged.ValueBool() {
+			unmanaged = append(unmanaged, describeZoneRecord(z))
+			continue
+		}
+		records = append(records, zoneRecordModelFromAPI(z, m, ok))
+	}
+	addUnmanagedWarning(&resp.Diagnostics, domain, unmanaged)
+
+	state.ID = types.StringValue(targetService)
+	state.Records = records
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dnsZoneRecordsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state dnsZoneRecordsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx, cancel := withTimeout(ctx, plan.Timeouts.Update, defaultZoneTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed, d := getManagedKeys(ctx, req.Private)
+	resp.Diagnostics.Append(d...)
+	if managed == nil {
+		managed = zoneRecordKeys(state.Domain.ValueString(), state.Records)
+	}
+	if plan.Service.IsUnknown() && plan.Domain.Equal(state.Domain) {
+		plan.Service = state.Service
+	}
+
+	r.converge(ctx, &plan, managed, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setManagedKeys(ctx, resp.Private, zoneRecordKeys(plan.Domain.ValueString(), plan.Records))...)
+
+	plan.ID = types.StringValue(zoneTargetService(plan.Domain, plan.Service))
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *dnsZoneRecordsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dnsZoneRecordsModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := withTimeout(ctx, state.Timeouts.Delete, defaultZoneTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the records declared by this resource are removed, never the whole zone
+	domain := state.Domain.ValueString()
+	managed, d := getManagedKeys(ctx, req.Private)
+	resp.Diagnostics.Append(d...)
+	if managed == nil {
+		managed = zoneRecordKeys(domain, state.Records)
+	}
+	if !resolveServiceAttr(ctx, r.client, state.Domain, &state.Service, &resp.Diagnostics) {
+		return
+	}
+	targetService := zoneTargetService(state.Domain, state.Service)
+	current, err := r.client.ListRecords(ctx, targetService, "", "", "", nil)
+	if err != nil {
+		if IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Error reading zone records", err.Error())
+		return
+	}
+	changes := planZoneChanges(current, nil, domain, managed, false)
+	if err := applyZoneChanges(ctx, r.client, targetService, changes); err != nil {
+		resp.Diagnostics.AddError("Error deleting zone records", err.Error())
+	}
+}
+
+func (r *dnsZoneRecordsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Supported formats:
+	//   <domain>             e.g. example.com
+	//   <domain>:<service>   e.g. example.com:12345678
+	parts := strings.Split(req.ID, ":")
+	if len(parts) > 2 || parts[0] == "" {
+		resp.Diagnostics.AddError("Invalid import format", "Expected <domain> or <domain>:<service>")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[len(parts)-1])...)
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service"), parts[1])...)
+	}
+}
+
+// converge applies the plan's records to the zone. managed holds the keys of
+// the records declared by the resource before this apply.
+func (r *dnsZoneRecordsResource) converge(ctx context.Context, plan *dnsZoneRecordsModel, managed map[string]bool, diags *diag.Diagnostics) {
+	domain := plan.Domain.ValueString()
+	if !resolveServiceAttr(ctx, r.client, plan.Domain, &plan.Service, diags) {
+		return
+	}
+	targetService := zoneTargetService(plan.Domain, plan.Service)
+
+	desired := make([]zoneRecord, 0, len(plan.Records))
+	for _, m := range plan.Records {
+		z := m.toZoneRecord(domain)
+		if strings.EqualFold(z.Type, "SOA") || (strings.EqualFold(z.Type, "NS") && z.Name == "") {
+			diags.AddError("Unsupported record", fmt.Sprintf("%s is managed by Active24 and cannot be listed in records.", describeZoneRecord(z)))
+			return
+		}
+		if z.Type != "CAA" && z.Content == "" {
+			diags.AddError("Missing content", fmt.Sprintf("%s: the 'content' attribute is required for non-CAA records.", describeZoneRecord(z)))
+			return
+		}
+		desired = append(desired, z)
+	}
+	current, err := r.client.ListRecords(ctx, targetService, "", "", "", nil)
+	if err != nil {
+		diags.AddError("Error reading zone records", err.Error())
+		return
+	}
+	changes := planZoneChanges(current, desired, domain, managed, plan.DeleteUnmanaged.ValueBool())
+	if err := applyZoneChanges(ctx, r.client, targetService, changes); err != nil {
+		diags.AddError("Error applying zone records", err.Error())
+		return
+	}
+	var unmanaged []string
+	for _, rec := range changes.Unmanaged {
+		unmanaged = append(unmanaged, describeZoneRecord(zoneRecordFromAPI(rec, domain)))
+	}
+	addUnmanagedWarning(diags, domain, unmanaged)
+}
+
+func addUnmanagedWarning(diags *diag.Diagnostics, domain string, unmanaged []string) {
+	if len(unmanaged) == 0 {
+		return
+	}
+	diags.AddWarning("Unmanaged records in zone",
+		fmt.Sprintf("Zone %s contains %d record(s) not declared in the configuration. They are kept because delete_unmanaged is false:\n  %s",
+			domain, len(unmanaged), strings.Join(unmanaged, "\n  ")))
+}
+
+// privateManagedKey is the private state key holding the record keys declared
+// in configuration at the last apply. Records in state but not in this list
+// were adopted by refresh and are not deleted when delete_unmanaged is false.
+const privateManagedKey = "managed_records"
+
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+func getManagedKeys(ctx context.Context, private privateStateGetter) (map[string]bool, diag.Diagnostics) {
+	raw, diags := private.GetKey(ctx, privateManagedKey)
+	if diags.HasError() || len(raw) == 0 {
+		return nil, diags
+	}
+	var keys []string
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		diags.AddError("Invalid private state", err.Error())
+		return nil, diags
+	}
+	managed := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		managed[k] = true
+	}
+	return managed, diags
+}
+
+func setManagedKeys(ctx context.Context, private privateStateSetter, managed map[string]bool) diag.Diagnostics {
+	keys := make([]string, 0, len(managed))
+	for k := range managed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	raw, err := json.Marshal(keys)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid private state", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, privateManagedKey, raw)
+}
+
+func zoneRecordKeys(domain string, records []dnsZoneRecordModel) map[string]bool {
+	keys := make(map[string]bool, len(records))
+	for _, m := range records {
+		keys[m.toZoneRecord(domain).key()] = true
+	}
+	return keys
+}
+
+func (m dnsZoneRecordModel) toZoneRecord(domain string) zoneRecord {
+	z := zoneRecord{
+		Name:     relativeName(m.Name.ValueString(), domain),
+		Type:     strings.ToUpper(m.Type.ValueString()),
+		Content:  m.Content.ValueString(),
+		TTL:      defaultRecordTTL,
+		Priority: m.Priority.ValueInt64Pointer(),
+	}
+	if !m.TTL.IsNull() {
+		z.TTL = m.TTL.ValueInt64()
+	}
+	if z.Type == "CAA" {
+		z.CAAValue = m.CAAValue.ValueString()
+		z.CAAFlags = m.CAAFlags.ValueInt64Pointer()
+		if z.CAAFlags == nil {
+			z.CAAFlags = ptrI(0)
+		}
+		z.CAATag = m.CAATag.ValueString()
+		z.Content = ""
+	}
+	return z
+}
+
+// zoneRecordModelFromAPI builds the state element for z. When the record was
+// already in state (hasPrior), the prior element is kept so the user's
+// spelling and omitted optional attributes do not show as drift.
+func zoneRecordModelFromAPI(z zoneRecord, prior dnsZoneRecordModel, hasPrior bool) dnsZoneRecordModel {
+	if hasPrior {
+		if !(prior.TTL.IsNull() && z.TTL == defaultRecordTTL) {
+			prior.TTL = types.Int64Value(z.TTL)
+		}
+		if !equalInt64Ptr(prior.Priority.ValueInt64Pointer(), z.Priority) {
+			prior.Priority = types.Int64PointerValue(z.Priority)
+		}
+		return prior
+	}
+
+	m := dnsZoneRecordModel{
+		Name:     types.StringValue(denormalizeNameFromAPI(z.Name)),
+		Type:     types.StringValue(z.Type),
+		Content:  types.StringValue(z.Content),
+		TTL:      types.Int64Value(z.TTL),
+		Priority: types.Int64PointerValue(z.Priority),
+		CAAValue: types.StringNull(),
+		CAAFlags: types.Int64Null(),
+		CAATag:   types.StringNull(),
+	}
+	if z.Type == "CAA" {
+		m.Content = types.StringNull()
+		m.CAAValue = types.StringValue(z.CAAValue)
+		m.CAAFlags = types.Int64PointerValue(z.CAAFlags)
+		m.CAATag = types.StringValue(z.CAATag)
+	}
+	return m
+}
+
+func describeZoneRecord(z zoneRecord) string {
+	value := z.Content
+	if z.Type == "CAA" {
+		var flags int64
+		if z.CAAFlags != nil {
+			flags = *z.CAAFlags
+		}
+		value = fmt.Sprintf("%d %s %q", flags, z.CAATag, z.CAAValue)
+	}
+	return fmt.Sprintf("%s %s %s", denormalizeNameFromAPI(z.Name), z.Type, value)
+}
+
+func zoneTargetService(domain, service types.String) string {
+	if !service.IsNull() && !service.IsUnknown() && service.ValueString() != "" {
+		return service.ValueString()
+	}
+	return toASCII(domain.ValueString())
+}
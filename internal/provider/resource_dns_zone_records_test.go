@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/JoystiC/terraform-provider-active24/internal/fakeapi"
+)
+
+func TestAccDNSZoneRecords_authoritative(t *testing.T) {
+	srv := newTestAccServer(t)
+	srv.FQDNNames = true
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "SOA", Content: "ns1.active24.cz. hostmaster.active24.cz. 1 3600 900 604800 3600", TTL: 3600})
+	srv.Put(testAccService, fakeapi.Record{Name: "", Type: "NS", Content: "ns1.active24.cz", TTL: 3600})
+	srv.Put(testAccService, fakeapi.Record{Name: "stale", Type: "A", Content: "10.9.9.9", TTL: 300})
+	srv.Put(testAccService, fakeapi.Record{Name: "www", Type: "A", Content: "10.0.0.1", TTL: 600})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + testAccDNSZoneRecordsConfig(true, `
+    { name = "www", type = "A", content = "10.0.0.1", ttl = 300 },
+    { name = "@", type = "MX", content = "mail.example.com", priority = 10 },
+    { name = "@", type = "CAA", caa_flags = 0, caa_tag = "issue", caa_value = "letsencrypt.org" },
+    { name = "_sip._tcp", type = "SRV", content = "5 5060 sip.example.com", priority = 10 },
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("active24_dns_zone_records.test", "records.#", "4"),
+					testAccCheckZone(srv, map[string]bool{
+						"@ SOA": true, "@ NS": true, "www A 10.0.0.1 300": true,
+						"@ MX mail.example.com 3600": true, "@ CAA letsencrypt.org 3600": true,
+						"_sip._tcp SRV sip.example.com 3600": true,
+					}),
+				),
+			},
+			{
+				// A record added out of band shows up as drift
+				PreConfig: func() {
+					srv.Put(testAccService, fakeapi.Record{Name: "manual", Type: "TXT", Content: "hello", TTL: 3600})
+				},
+				Config: testAccProviderConfig(srv) + testAccDNSZoneRecordsConfig(true, `
+    { name = "www", type = "A", content = "10.0.0.1", ttl = 300 },
+    { name = "@", type = "MX", content = "mail.example.com", priority = 10 },
+    { name = "@", type = "CAA", caa_flags = 0, caa_tag = "issue", caa_value = "letsencrypt.org" },
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// Converging removes it again; changing a TTL updates in place
+				Config: testAccProviderConfig(srv) + testAccDNSZoneRecordsConfig(true, `
+    { name = "www", type = "A", content = "10.0.0.1", ttl = 900 },
+    { name = "@", type = "MX", content = "mail.example.com", priority = 10 },
+    { name = "@", type = "CAA", caa_flags = 0, caa_tag = "issue", caa_value = "letsencrypt.org" },
+`),
+				Check: testAccCheckZone(srv, map[string]bool{
+					"@ SOA": true, "@ NS": true, "www A 10.0.0.1 900": true,
+					"@ MX mail.example.com 3600": true, "@ CAA letsencrypt.org 3600": true,
+				}),
+			},
+			{
+				// With delete_unmanaged = false, out-of-band records are kept
+				PreConfig: func() {
+					srv.Put(testAccService, fakeapi.Record{Name: "manual", Type: "TXT", Content: "hello", TTL: 3600})
+				},
+				Config: testAccProviderConfig(srv) + testAccDNSZoneRecordsConfig(false, `
+    { name = "www", type = "A", content = "10.0.0.1", ttl = 900 },
+    { name = "@", type = "CAA", caa_flags = 0, caa_tag = "issue", caa_value = "letsencrypt.org" },
+`),
+				Check: testAccCheckZone(srv, map[string]bool{
+					"@ SOA": true, "@ NS": true, "www A 10.0.0.1 900": true,
+					"@ CAA letsencrypt.org 3600": true, "manual TXT hello 3600": true,
+				}),
+			},
+			{
+				ResourceName:            "active24_dns_zone_records.test",
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("%s:%s", testAccDomain, testAccService),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"records", "delete_unmanaged"},
+			},
+		},
+	})
+}
+
+func testAccDNSZoneRecordsConfig(deleteUnmanaged bool, records string) string {
+	return fmt.Sprintf(`
+resource "active24_dns_zone_records" "test" {
+  domain           = %q
+  service          = %q
+  delete_unmanaged = %t
+  records = [%s]
+}
+`, testAccDomain, testAccService, deleteUnmanaged, records)
+}
+
+// testAccCheckZone compares the fake zone against "name type [content ttl]"
+// descriptions; SOA and NS are described by name and type only.
+func testAccCheckZone(srv *fakeapi.Server, want map[string]bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		got := map[string]bool{}
+		for _, rec := range srv.Records(testAccService) {
+			name := rec.Name
+			if name == "" {
+				name = "@"
+			}
+			desc := name + " " + rec.Type
+			if rec.Type != "SOA" && rec.Type != "NS" {
+				desc += fmt.Sprintf(" %s %d", rec.Content, rec.TTL)
+			}
+			got[desc] = true
+		}
+		for k := range want {
+			if !got[k] {
+				return fmt.Errorf("missing %q in zone, have %v", k, got)
+			}
+		}
+		if len(got) != len(want) {
+			return fmt.Errorf("zone has %d records, expected %d: %v", len(got), len(want), got)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Active24 v2 addresses DNS records by the numeric ID of the service the
+// zone belongs to, not by the domain name. When a configuration leaves
+// `service` unset, the ID is looked up in the account's service list.
+
+// Service is an Active24 service: a domain, DNS zone, hosting or mailbox.
+type Service struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	Type           string `json:"serviceName"`
+	Status         string `json:"status"`
+	ExpirationDate string `json:"expirationDate,omitempty"`
+}
+
+// servicesPage is the response model for the paginated service list.
+type servicesPage struct {
+	Data        []Service `json:"data"`
+	CurrentPage int       `json:"currentPage"`
+	TotalPages  int       `json:"totalPages"`
+}
+
+// ListServices lists every service on the account, following pagination.
+// The result also refreshes the cache used by ResolveService.
+func (c *Client) ListServices(ctx context.Context) ([]Service, error) {
+	services, err := c.listServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.servicesMu.Lock()
+	c.services = services
+	c.servicesMu.Unlock()
+	return append([]Service(nil), services...), nil
+}
+
+func (c *Client) listServices(ctx context.Context) ([]Service, error) {
+	var services []Service
+	for page := 1; page <= maxListPages; page++ {
+		q := neturl.Values{}
+		q.Set("page", strconv.Itoa(page))
+		q.Set("rowsPerPage", strconv.Itoa(listPageSize))
+		var out servicesPage
+		if err := c.do(ctx, http.MethodGet, c.buildURL("service")+"?"+q.Encode(), nil, &out); err != nil {
+			return nil, err
+		}
+		services = append(services, out.Data...)
+		if len(out.Data) == 0 || out.TotalPages <= page {
+			return services, nil
+		}
+	}
+	return nil, fmt.Errorf("active24: service list exceeded %d pages", maxListPages)
+}
+
+// ResolveService returns the ID of the service hosting the DNS zone of
+// domain. The service list is fetched once per client and then cached.
+// Concurrent lookups share one fetch, run with the first caller's context,
+// instead of each listing the services. A caller whose context ends stops
+// waiting for it.
+func (c *Client) ResolveService(ctx context.Context, domain string) (string, error) {
+	c.servicesMu.Lock()
+	services := c.services
+	c.servicesMu.Unlock()
+	if services == nil {
+		ch := c.servicesLoad.DoChan("services", func() (any, error) {
+			return c.ListServices(ctx)
+		})
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case res := <-ch:
+			if res.Err != nil {
+				return "", fmt.Errorf("listing services to resolve %q: %w", domain, res.Err)
+			}
+			services = res.Val.([]Service)
+		}
+	}
+	return resolveService(services, domain)
+}
+
+// resolveService picks the service of domain from services. A dedicated DNS
+// service wins over the domain registration, which also carries the zone.
+func resolveService(services []Service, domain string) (string, error) {
+	zone := strings.ToLower(strings.TrimSuffix(toASCII(domain), "."))
+	var found *Service
+	for i := range services {
+		s := &services[i]
+		if strings.ToLower(strings.TrimSuffix(toASCII(s.Name), ".")) != zone {
+			continue
+		}
+		switch strings.ToLower(s.Type) {
+		case "dns":
+			return strconv.FormatInt(s.ID, 10), nil
+		case "domain":
+			if found == nil {
+				found = s
+			}
+		}
+	}
+	if found == nil {
+		return "", fmt.Errorf("no DNS or domain service for %q found on the account; set `service` to the Active24 service ID", domain)
+	}
+	return strconv.FormatInt(found.ID, 10), nil
+}
+
+// resolveServiceValue fills an unset service attribute with the service
+// resolved from domain.
+func resolveServiceValue(ctx context.Context, client DNSAPI, domain types.String, service *types.String) error {
+	if !service.IsNull() && !service.IsUnknown() {
+		return nil
+	}
+	id, err := client.ResolveService(ctx, domain.ValueString())
+	if err != nil {
+		return err
+	}
+	*service = types.StringValue(id)
+	return nil
+}
+
+// resolveServiceAttr is resolveServiceValue reporting the error on the
+// service attribute. It reports false when resolution failed.
+func resolveServiceAttr(ctx context.Context, client DNSAPI, domain types.String, service *types.String, diags *diag.Diagnostics) bool {
+	if err := resolveServiceValue(ctx, client, domain, service); err != nil {
+		diags.AddAttributeError(path.Root("service"), "Unable to determine service", err.Error())
+		return false
+	}
+	return true
+}
+
+// serviceValidators reject an explicit empty service. It would be replaced
+// by the resolved ID and no longer match the configuration.
+func serviceValidators() []validator.String {
+	return []validator.String{nonEmptyServiceValidator{}}
+}
+
+type nonEmptyServiceValidator struct{}
+
+func (nonEmptyServiceValidator) Description(context.Context) string {
+	return "Must not be empty; leave it unset to look the service up by domain."
+}
+
+func (v nonEmptyServiceValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (nonEmptyServiceValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.ValueString() != "" {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid service",
+		"`service` must not be empty. Leave it unset to look up the service by `domain`.")
+}
+
+// servicePlanModifiers make `service` keep its resolved value while the
+// configuration leaves it unset. It is unknown until apply for new resources
+// and when the domain changes, and only a change between two known services
+// forces replacement.
+func servicePlanModifiers() []planmodifier.String {
+	return []planmodifier.String{
+		resolvedServiceModifier{},
+		stringplanmodifier.RequiresReplaceIf(serviceChanged,
+			"Changing the service forces a new resource.",
+			"Changing the service forces a new resource."),
+	}
+}
+
+type resolvedServiceModifier struct{}
+
+func (resolvedServiceModifier) Description(context.Context) string {
+	return "Keeps the resolved service while the domain is unchanged."
+}
+
+func (m resolvedServiceModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (resolvedServiceModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() || req.StateValue.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+	var planDomain, stateDomain types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("domain"), &planDomain)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("domain"), &stateDomain)...)
+	if planDomain.Equal(stateDomain) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// serviceChanged requires replacement when a known service changes. An older
+// state without a service is filled in place.
+func serviceChanged(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull() && !req.PlanValue.IsUnknown() &&
+		req.PlanValue.ValueString() != req.StateValue.ValueString()
+}
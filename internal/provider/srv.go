@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxSRVValue is the upper bound of the 16-bit SRV priority, weight and port.
+const maxSRVValue = 65535
+
+// Active24 stores SRV records with separate priority, weight and port fields
+// and only the target in content. Terraform users and zone files write the
+// presentation form "weight port target" (optionally prefixed by priority),
+// so both forms are converted here.
+
+// srvLabel returns a service or protocol label with its leading underscore,
+// so "sip" and "_sip" are equivalent.
+func srvLabel(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasPrefix(s, "_") {
+		return s
+	}
+	return "_" + s
+}
+
+// srvName composes the owner name _service._proto.name from a relative name.
+// An empty name places the record at the zone apex.
+func srvName(service, proto, name string) string {
+	prefix := srvLabel(service) + "." + srvLabel(proto)
+	if name == "" {
+		return prefix
+	}
+	return prefix + "." + name
+}
+
+// splitSRVName strips the _service._proto prefix from a relative owner name.
+// It reports false when name does not start with that prefix.
+func splitSRVName(name, service, proto string) (string, bool) {
+	prefix := strings.ToLower(srvLabel(service) + "." + srvLabel(proto))
+	lower := strings.ToLower(name)
+	switch {
+	case lower == prefix:
+		return "", true
+	case strings.HasPrefix(lower, prefix+"."):
+		return name[len(prefix)+1:], true
+	}
+	return "", false
+}
+
+// srvData is the RDATA of an SRV record.
+type srvData struct {
+	Priority *int64
+	Weight   int64
+	Port     int64
+	Target   string
+}
+
+// parseSRVContent parses "weight port target" or "priority weight port target".
+func parseSRVContent(s string) (srvData, error) {
+	fields := strings.Fields(s)
+	var out srvData
+	var nums []string
+	switch len(fields) {
+	case 3:
+		nums = fields[:2]
+	case 4:
+		nums = fields[:3]
+	default:
+		return out, fmt.Errorf("SRV content %q must be \"weight port target\" or \"priority weight port target\"", s)
+	}
+	values := make([]int64, len(nums))
+	for i, f := range nums {
+		v, err := strconv.ParseInt(f, 10, 64)
+		if err != nil || v < 0 || v > maxSRVValue {
+			return out, fmt.Errorf("SRV content %q: %q is not a number between 0 and %d", s, f, maxSRVValue)
+		}
+		values[i] = v
+	}
+	if len(values) == 3 {
+		out.Priority = ptrI(values[0])
+		values = values[1:]
+	}
+	out.Weight, out.Port = values[0], values[1]
+	out.Target = fields[len(fields)-1]
+	return out, nil
+}
+
+// formatSRVContent renders weight, port and target in presentation form.
+func formatSRVContent(weight, port int64, target string) string {
+	return fmt.Sprintf("%d %d %s", weight, port, target)
+}
+
+// recordContent returns the content of an API record in presentation form.
+// It differs from rec.Content only for SRV records returned with separate
+// weight and port fields.
+func recordContent(rec DNSRecord) string {
+	if strings.EqualFold(rec.Type, "SRV") && rec.Weight != nil && rec.Port != nil {
+		return formatSRVContent(*rec.Weight, *rec.Port, rec.Content)
+	}
+	return rec.Content
+}
+
+// applySRVContent moves an SRV presentation-form content into the separate
+// API fields of req. Requests for other types are left unchanged.
+func applySRVContent(req *RecordRequest) error {
+	if !strings.EqualFold(req.Type, "SRV") || req.Weight != nil {
+		return nil
+	}
+	srv, err := parseSRVContent(req.Content)
+	if err != nil {
+		return err
+	}
+	if req.Priority == nil {
+		req.Priority = srv.Priority
+	}
+	req.Weight = ptrI(srv.Weight)
+	req.Port = ptrI(srv.Port)
+	req.Content = srv.Target
+	return nil
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// Resources accept a timeouts block bounding each operation as a whole:
+// retries, rate-limit waits and read-back lookups all share the deadline. A
+// single HTTP request is additionally limited by the provider's
+// request_timeout.
+const (
+	defaultRecordTimeout = 5 * time.Minute
+	// Zone-wide resources may issue one request per record
+	defaultZoneTimeout = 20 * time.Minute
+)
+
+func timeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true})
+}
+
+// withTimeout returns ctx bounded by the configured timeout of an operation,
+// e.g. withTimeout(ctx, plan.Timeouts.Create, defaultRecordTimeout, &resp.Diagnostics).
+func withTimeout(ctx context.Context, configured func(context.Context, time.Duration) (time.Duration, diag.Diagnostics), def time.Duration, diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	d, dd := configured(ctx, def)
+	diags.Append(dd...)
+	return context.WithTimeout(ctx, d)
+}
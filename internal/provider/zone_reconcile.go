@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultRecordTTL is the TTL used when a record does not specify one.
+const defaultRecordTTL = 3600
+
+// zoneRecord is one record of a zone-wide desired state, independent of any
+// Active24 record ID. Name is relative to the zone ("" for apex).
+type zoneRecord struct {
+	Name     string
+	Type     string
+	Content  string
+	TTL      int64
+	Priority *int64
+	CAAValue string
+	CAAFlags *int64
+	CAATag   string
+}
+
+// key identifies a record by owner, type and value. Two records with the same
+// key are the same record; TTL and MX/SRV priority can be updated in place.
+// Values are compared in normalized form, so equivalent spellings match.
+func (z zoneRecord) key() string {
+	rtype := strings.ToUpper(z.Type)
+	value := normalizeContent(rtype, z.Content)
+	if rtype == "CAA" {
+		var flags int64
+		if z.CAAFlags != nil {
+			flags = *z.CAAFlags
+		}
+		value = fmt.Sprintf("%d %s %s", flags, strings.ToLower(z.CAATag), normalizeContent(rtype, z.CAAValue))
+	}
+	return strings.ToLower(z.Name) + "|" + rtype + "|" + value
+}
+
+// request returns the API payload for z, following the same CAA rules as
+// active24_dns_record: CAA fields only for CAA, with content set to the value.
+func (z zoneRecord) request() RecordRequest {
+	req := RecordRequest{
+		Name:     z.Name,
+		Type:     strings.ToUpper(z.Type),
+		Content:  z.Content,
+		TTL:      z.TTL,
+		Priority: z.Priority,
+	}
+	if req.TTL == 0 {
+		req.TTL = defaultRecordTTL
+	}
+	if req.Type == "CAA" {
+		req.CAAValue = z.CAAValue
+		req.Flags = z.CAAFlags
+		if req.Flags == nil {
+			req.Flags = ptrI(0)
+		}
+		req.Tag = z.CAATag
+		if req.Content == "" {
+			req.Content = z.CAAValue
+		}
+	}
+	if req.Type == "TXT" {
+		req.Content = txtRequestContent(req.Content)
+	}
+	// Malformed SRV content is sent as is and rejected by the API
+	_ = applySRVContent(&req)
+	req.Content = contentForAPI(req.Type, req.Content)
+	return req
+}
+
+// zoneRecordFromAPI converts an API record into a zoneRecord with a relative name.
+func zoneRecordFromAPI(rec DNSRecord, domain string) zoneRecord {
+	z := zoneRecord{
+		Name:     relativeName(rec.Name, domain),
+		Type:     strings.ToUpper(rec.Type),
+		Content:  recordContent(rec),
+		TTL:      rec.TTL,
+		Priority: rec.Priority,
+	}
+	if z.Type == "CAA" {
+		z.CAAValue = rec.CAAValue
+		if z.CAAValue == "" {
+			z.CAAValue = rec.Content
+		}
+		z.CAAFlags = rec.Flags
+		if z.CAAFlags == nil {
+			z.CAAFlags = ptrI(0)
+		}
+		z.CAATag = rec.Tag
+		z.Content = ""
+	}
+	return z
+}
+
+// isZoneSystemRecord reports records that are managed by Active24 itself and
+// never created or deleted by zone-wide resources: SOA and the apex NS set.
+func isZoneSystemRecord(rec DNSRecord, domain string) bool {
+	switch strings.ToUpper(rec.Type) {
+	case "SOA":
+		return true
+	case "NS":
+		return relativeName(rec.Name, domain) == ""
+	}
+	return false
+}
+
+type zoneUpdate struct {
+	ID     int64
+	Record zoneRecord
+}
+
+// zoneChanges is the set of API calls needed to converge a zone.
+type zoneChanges struct {
+	Create []zoneRecord
+	Update []zoneUpdate
+	Delete []DNSRecord
+	// Unmanaged lists existing records that are neither desired nor
+	// previously managed and were kept because deleteUnmanaged is false.
+	Unmanaged []DNSRecord
+}
+
+// planZoneChanges diffs the existing records against the desired set.
+// managed holds the keys of records owned by the resource in prior state, so
+// records removed from the configuration are deleted even when
+// deleteUnmanaged is false.
+func planZoneChanges(current []DNSRecord, desired []zoneRecord, domain string, managed map[string]bool, deleteUnmanaged bool) zoneChanges {
+	var changes zoneChanges
+
+	existing := map[string][]DNSRecord{}
+	for _, rec := range current {
+		if isZoneSystemRecord(rec, domain) {
+			continue
+		}
+		k := zoneRecordFromAPI(rec, domain).key()
+		existing[k] = append(existing[k], rec)
+	}
+
+	wanted := map[string]bool{}
+	for _, want := range desired {
+		k := want.key()
+		if wanted[k] {
+			continue
+		}
+		wanted[k] = true
+
+		matches := existing[k]
+		if len(matches) == 0 {
+			changes.Create = append(changes.Create, want)
+			continue
+		}
+		have := zoneRecordFromAPI(matches[0], domain)
+		req := want.request()
+		if have.TTL != req.TTL || !equalInt64Ptr(have.Priority, req.Priority) {
+			changes.Update = append(changes.Update, zoneUpdate{ID: matches[0].ID, Record: want})
+		}
+		// Duplicates of a desired record are surplus
+		for _, dup := range matches[1:] {
+			changes.Delete = append(changes.Delete, dup)
+		}
+	}
+
+	keys := make([]string, 0, len(existing))
+	for k := range existing {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if wanted[k] {
+			continue
+		}
+		if deleteUnmanaged || managed[k] {
+			changes.Delete = append(changes.Delete, existing[k]...)
+		} else {
+			changes.Unmanaged = append(changes.Unmanaged, existing[k]...)
+		}
+	}
+	return changes
+}
+
+// applyZoneChanges performs deletes first (so replaced records never collide
+// with their successors), then updates and creates.
+func applyZoneChanges(ctx context.Context, client DNSAPI, service string, changes zoneChanges) error {
+	for _, rec := range changes.Delete {
+		if err := client.DeleteRecord(ctx, service, rec.ID); err != nil && !IsNotFound(err) {
+			return fmt.Errorf("deleting %s record %q (ID %d): %w", rec.Type, rec.Name, rec.ID, err)
+		}
+	}
+	for _, u := range changes.Update {
+		if _, err := client.UpdateRecord(ctx, service, u.ID, u.Record.request()); err != nil {
+			return fmt.Errorf("updating %s record %q (ID %d): %w", u.Record.Type, denormalizeNameFromAPI(u.Record.Name), u.ID, err)
+		}
+	}
+	for _, z := range changes.Create {
+		if _, err := client.CreateRecord(ctx, service, z.request()); err != nil {
+			return fmt.Errorf("creating %s record %q: %w", z.Type, denormalizeNameFromAPI(z.Name), err)
+		}
+	}
+	return nil
+}
+
+func equalInt64Ptr(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,605 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Zones are exchanged with other DNS software as RFC 1035 master files
+// ("zone files"). Records are written with names relative to $ORIGIN and host
+// name values fully qualified, so the output loads into BIND unchanged.
+
+// renderZoneFile renders records as a master file for domain. The output is
+// deterministic: records are sorted by name, type and value, and $TTL is the
+// most common TTL so only the exceptions carry an explicit TTL.
+func renderZoneFile(domain string, records []zoneRecord) string {
+	origin := strings.TrimSuffix(toASCII(domain), ".") + "."
+	records = append([]zoneRecord(nil), records...)
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Name != b.Name {
+			return zoneNameLess(a.Name, b.Name)
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return zoneRData(a) < zoneRData(b)
+	})
+	ttl := commonTTL(records)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s\n", origin)
+	fmt.Fprintf(&b, "$TTL %d\n", ttl)
+	tw := tabwriter.NewWriter(&b, 0, 8, 1, ' ', 0)
+	for _, z := range records {
+		recTTL := ""
+		if z.TTL != 0 && z.TTL != ttl {
+			recTTL = strconv.FormatInt(z.TTL, 10)
+		}
+		fmt.Fprintf(tw, "%s\t%s\tIN\t%s\t%s\n", denormalizeNameFromAPI(z.Name), recTTL, strings.ToUpper(z.Type), zoneRData(z))
+	}
+	_ = tw.Flush()
+	return b.String()
+}
+
+// zoneNameLess orders the apex first, then names by their labels from the
+// zone downwards, so a subdomain follows its parent. Names that differ only
+// in case are ordered case-sensitively to keep the output stable.
+func zoneNameLess(a, b string) bool {
+	la, lb := reverseLabels(a), reverseLabels(b)
+	for i := 0; i < len(la) && i < len(lb); i++ {
+		if la[i] != lb[i] {
+			return la[i] < lb[i]
+		}
+	}
+	if len(la) != len(lb) {
+		return len(la) < len(lb)
+	}
+	return a < b
+}
+
+func reverseLabels(name string) []string {
+	if name == "" {
+		return nil
+	}
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+	return labels
+}
+
+// commonTTL returns the most frequent TTL of records, preferring the lower
+// value on a tie and defaultRecordTTL for an empty zone.
+func commonTTL(records []zoneRecord) int64 {
+	counts := map[int64]int{}
+	for _, z := range records {
+		if z.TTL != 0 {
+			counts[z.TTL]++
+		}
+	}
+	best, bestCount := int64(defaultRecordTTL), 0
+	for ttl, n := range counts {
+		if n > bestCount || (n == bestCount && ttl < best) {
+			best, bestCount = ttl, n
+		}
+	}
+	return best
+}
+
+// zoneRData renders the RDATA of z in presentation form.
+func zoneRData(z zoneRecord) string {
+	switch strings.ToUpper(z.Type) {
+	case "CNAME", "NS", "PTR":
+		return absoluteHost(z.Content)
+	case "MX":
+		return fmt.Sprintf("%d %s", priorityOf(z), absoluteHost(z.Content))
+	case "SRV":
+		if srv, err := parseSRVContent(z.Content); err == nil {
+			priority := priorityOf(z)
+			if srv.Priority != nil {
+				priority = *srv.Priority
+			}
+			return fmt.Sprintf("%d %d %d %s", priority, srv.Weight, srv.Port, absoluteHost(srv.Target))
+		}
+	case "TXT":
+		parts, ok := parseCharacterStrings(z.Content)
+		if !ok {
+			parts = splitTXT(z.Content)
+		}
+		quoted := make([]string, len(parts))
+		for i, p := range parts {
+			quoted[i] = zoneQuote(p)
+		}
+		return strings.Join(quoted, " ")
+	case "CAA":
+		var flags int64
+		if z.CAAFlags != nil {
+			flags = *z.CAAFlags
+		}
+		value := z.CAAValue
+		if value == "" {
+			value = unquote(z.Content)
+		}
+		return fmt.Sprintf("%d %s %s", flags, strings.ToLower(z.CAATag), zoneQuote(value))
+	case "TLSA", "SSHFP":
+		if d, err := parseHexRData(z.Type, z.Content); err == nil {
+			return d.String()
+		}
+	}
+	return z.Content
+}
+
+func priorityOf(z zoneRecord) int64 {
+	if z.Priority != nil {
+		return *z.Priority
+	}
+	return 0
+}
+
+// absoluteHost returns a host name in A-label form with the trailing dot of
+// a fully qualified name.
+func absoluteHost(host string) string {
+	host = toASCII(strings.TrimSpace(host))
+	if host == "" || strings.HasSuffix(host, ".") {
+		return host
+	}
+	return host + "."
+}
+
+// zoneQuote renders s as a quoted character-string. Quotes and backslashes
+// are escaped, and bytes outside printable ASCII are written as \DDD.
+func zoneQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c >= 0x7f:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// zoneToken is one field of a master file line. Quoted character-strings are
+// unescaped by the lexer; unquoted fields are kept as written.
+type zoneToken struct {
+	Text   string
+	Quoted bool
+}
+
+// zoneLine is a logical master file line: parenthesized continuations are
+// joined and comments removed. Continued reports a line starting with
+// whitespace, which repeats the previous owner name.
+type zoneLine struct {
+	Num       int
+	Continued bool
+	Tokens    []zoneToken
+}
+
+// lexZoneFile splits a master file into logical lines.
+func lexZoneFile(text string) ([]zoneLine, error) {
+	var lines []zoneLine
+	cur := zoneLine{Num: 1}
+	num, depth, parenLine := 1, 0, 0
+	atLineStart := true
+	for i := 0; i < len(text); {
+		c := text[i]
+		if atLineStart && depth == 0 {
+			cur.Continued = c == ' ' || c == '\t'
+		}
+		atLineStart = false
+		switch {
+		case c == '\n':
+			if depth == 0 {
+				if len(cur.Tokens) > 0 {
+					lines = append(lines, cur)
+				}
+				cur = zoneLine{Num: num + 1}
+				atLineStart = true
+			}
+			num++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == ';':
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case c == '(':
+			if depth == 0 {
+				parenLine = num
+			}
+			depth++
+			i++
+		case c == ')':
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: unbalanced \")\"", num)
+			}
+			depth--
+			i++
+		case c == '"':
+			start := num
+			i++
+			var raw strings.Builder
+			closed := false
+			for i < len(text) {
+				if text[i] == '\\' && i+1 < len(text) {
+					raw.WriteString(text[i : i+2])
+					i += 2
+					continue
+				}
+				if text[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				if text[i] == '\n' {
+					num++
+				}
+				raw.WriteByte(text[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", start)
+			}
+			s, err := zoneUnescape(raw.String())
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", start, err)
+			}
+			cur.Tokens = append(cur.Tokens, zoneToken{Text: s, Quoted: true})
+		default:
+			start := i
+			for i < len(text) && !strings.ContainsRune(" \t\r\n;()\"", rune(text[i])) {
+				if text[i] == '\\' && i+1 < len(text) {
+					i++
+				}
+				i++
+			}
+			cur.Tokens = append(cur.Tokens, zoneToken{Text: text[start:i]})
+		}
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unbalanced \"(\"", parenLine)
+	}
+	if len(cur.Tokens) > 0 {
+		lines = append(lines, cur)
+	}
+	return lines, nil
+}
+
+// zoneUnescape resolves the \X and \DDD escapes of a master file field.
+func zoneUnescape(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+4 <= len(s) && isDigits(s[i+1:i+4]) {
+			v, _ := strconv.Atoi(s[i+1 : i+4])
+			if v > 255 {
+				return "", fmt.Errorf("invalid escape \\%s", s[i+1:i+4])
+			}
+			b.WriteByte(byte(v))
+			i += 3
+			continue
+		}
+		b.WriteByte(s[i+1])
+		i++
+	}
+	return b.String(), nil
+}
+
+// parseZoneFile parses an RFC 1035 master file for domain into zone records
+// with names relative to domain. $ORIGIN and $TTL directives, relative and
+// "@" names, omitted owners, TTLs and classes, parentheses and quoted strings
+// are supported. SOA and apex NS records are skipped because Active24
+// manages them.
+func parseZoneFile(text, domain string) ([]zoneRecord, error) {
+	lines, err := lexZoneFile(text)
+	if err != nil {
+		return nil, err
+	}
+	zone := strings.ToLower(strings.TrimSuffix(toASCII(domain), ".")) + "."
+	p := zoneParser{origin: zone, zone: zone, ttl: -1}
+	var records []zoneRecord
+	for _, line := range lines {
+		z, ok, err := p.parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.Num, err)
+		}
+		if ok {
+			records = append(records, z)
+		}
+	}
+	return records, nil
+}
+
+// zoneFileOrigin returns the domain of a master file from its first $ORIGIN
+// directive, which must come before the first record.
+func zoneFileOrigin(text string) (string, error) {
+	lines, err := lexZoneFile(text)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range lines {
+		if line.Tokens[0].Quoted || !strings.HasPrefix(line.Tokens[0].Text, "$") {
+			break
+		}
+		if strings.EqualFold(line.Tokens[0].Text, "$ORIGIN") && len(line.Tokens) == 2 && strings.HasSuffix(line.Tokens[1].Text, ".") {
+			return strings.TrimSuffix(line.Tokens[1].Text, "."), nil
+		}
+	}
+	return "", fmt.Errorf("the zone file must start with an $ORIGIN directive naming the zone")
+}
+
+// zoneParser holds the state carried from one master file line to the next.
+type zoneParser struct {
+	zone    string
+	origin  string
+	owner   string
+	ttl     int64
+	lastTTL int64
+}
+
+func (p *zoneParser) parseLine(line zoneLine) (zoneRecord, bool, error) {
+	tokens := line.Tokens
+	first := tokens[0].Text
+	if !tokens[0].Quoted && strings.HasPrefix(first, "$") {
+		return zoneRecord{}, false, p.directive(strings.ToUpper(first), tokens[1:])
+	}
+
+	if !line.Continued {
+		p.owner = p.absolute(first)
+		tokens = tokens[1:]
+	} else if p.owner == "" {
+		return zoneRecord{}, false, fmt.Errorf("record without owner name")
+	}
+	if p.owner != p.zone && !strings.HasSuffix(p.owner, "."+p.zone) {
+		return zoneRecord{}, false, fmt.Errorf("name %q is outside of zone %s", p.owner, p.zone)
+	}
+
+	ttl := int64(-1)
+	for len(tokens) > 0 && !tokens[0].Quoted {
+		f := strings.ToUpper(tokens[0].Text)
+		if f == "IN" {
+			tokens = tokens[1:]
+			continue
+		}
+		if f == "CH" || f == "HS" || f == "CS" {
+			return zoneRecord{}, false, fmt.Errorf("class %s is not supported, only IN", f)
+		}
+		if ttl < 0 && f != "" && f[0] >= '0' && f[0] <= '9' {
+			v, err := parseZoneTTL(f)
+			if err != nil {
+				return zoneRecord{}, false, err
+			}
+			ttl = v
+			tokens = tokens[1:]
+			continue
+		}
+		break
+	}
+	if len(tokens) == 0 {
+		return zoneRecord{}, false, fmt.Errorf("missing record type")
+	}
+	rtype := strings.ToUpper(tokens[0].Text)
+	rdata := tokens[1:]
+
+	switch {
+	case ttl >= 0:
+		p.lastTTL = ttl
+	case p.ttl >= 0:
+		ttl = p.ttl
+	case p.lastTTL > 0:
+		// RFC 1035: without $TTL a record inherits the last explicit TTL
+		ttl = p.lastTTL
+	default:
+		ttl = defaultRecordTTL
+	}
+
+	z := zoneRecord{
+		Name: relativeName(p.owner, strings.TrimSuffix(p.zone, ".")),
+		Type: rtype,
+		TTL:  ttl,
+	}
+	if rtype == "SOA" || (rtype == "NS" && z.Name == "") {
+		return zoneRecord{}, false, nil
+	}
+	if err := p.parseRData(&z, rdata); err != nil {
+		return zoneRecord{}, false, fmt.Errorf("%s record %q: %w", rtype, denormalizeNameFromAPI(z.Name), err)
+	}
+	return z, true, nil
+}
+
+func (p *zoneParser) directive(name string, args []zoneToken) error {
+	switch name {
+	case "$ORIGIN":
+		if len(args) != 1 {
+			return fmt.Errorf("$ORIGIN takes one domain name")
+		}
+		p.origin = p.absolute(args[0].Text)
+	case "$TTL":
+		if len(args) != 1 {
+			return fmt.Errorf("$TTL takes one TTL value")
+		}
+		v, err := parseZoneTTL(args[0].Text)
+		if err != nil {
+			return err
+		}
+		p.ttl = v
+	case "$INCLUDE", "$GENERATE":
+		return fmt.Errorf("%s is not supported", name)
+	default:
+		return fmt.Errorf("unknown directive %s", name)
+	}
+	return nil
+}
+
+// absolute qualifies name with the current origin. The result is lower-case,
+// in A-label form and ends with a dot.
+func (p *zoneParser) absolute(name string) string {
+	if name == "@" {
+		return p.origin
+	}
+	name = strings.ToLower(toASCII(name))
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "." + p.origin
+}
+
+// host qualifies a host name in RDATA and drops the trailing dot, the form
+// the API stores.
+func (p *zoneParser) host(t zoneToken) string {
+	if t.Text == "." {
+		return t.Text
+	}
+	return strings.TrimSuffix(p.absolute(t.Text), ".")
+}
+
+func (p *zoneParser) parseRData(z *zoneRecord, rdata []zoneToken) error {
+	fields := func(n int) error {
+		if len(rdata) != n {
+			return fmt.Errorf("expected %d fields, got %d", n, len(rdata))
+		}
+		return nil
+	}
+	number := func(t zoneToken, limit int64) (int64, error) {
+		v, err := strconv.ParseInt(t.Text, 10, 64)
+		if err != nil || v < 0 || v > limit {
+			return 0, fmt.Errorf("%q is not a number between 0 and %d", t.Text, limit)
+		}
+		return v, nil
+	}
+
+	switch z.Type {
+	case "A", "AAAA":
+		if err := fields(1); err != nil {
+			return err
+		}
+		z.Content = rdata[0].Text
+	case "CNAME", "NS", "PTR":
+		if err := fields(1); err != nil {
+			return err
+		}
+		z.Content = p.host(rdata[0])
+	case "MX":
+		if err := fields(2); err != nil {
+			return err
+		}
+		prio, err := number(rdata[0], maxSRVValue)
+		if err != nil {
+			return err
+		}
+		z.Priority = ptrI(prio)
+		z.Content = p.host(rdata[1])
+	case "SRV":
+		if err := fields(4); err != nil {
+			return err
+		}
+		values := make([]int64, 3)
+		for i := range values {
+			v, err := number(rdata[i], maxSRVValue)
+			if err != nil {
+				return err
+			}
+			values[i] = v
+		}
+		z.Priority = ptrI(values[0])
+		z.Content = formatSRVContent(values[1], values[2], p.host(rdata[3]))
+	case "TXT":
+		if len(rdata) == 0 {
+			return fmt.Errorf("missing text")
+		}
+		parts := make([]string, len(rdata))
+		for i, t := range rdata {
+			s, err := zoneTokenText(t)
+			if err != nil {
+				return err
+			}
+			parts[i] = s
+		}
+		z.Content = parts[0]
+		if len(parts) > 1 || len(parts[0]) > maxTXTStringLength {
+			z.Content = formatCharacterStrings(parts)
+		}
+	case "CAA":
+		if err := fields(3); err != nil {
+			return err
+		}
+		flags, err := number(rdata[0], 255)
+		if err != nil {
+			return err
+		}
+		value, err := zoneTokenText(rdata[2])
+		if err != nil {
+			return err
+		}
+		z.CAAFlags = ptrI(flags)
+		z.CAATag = strings.ToLower(rdata[1].Text)
+		z.CAAValue = value
+	case "TLSA", "SSHFP":
+		texts := make([]string, len(rdata))
+		for i, t := range rdata {
+			texts[i] = t.Text
+		}
+		d, err := parseHexRData(z.Type, strings.Join(texts, " "))
+		if err != nil {
+			return err
+		}
+		z.Content = d.String()
+	default:
+		return fmt.Errorf("record type %s is not supported", z.Type)
+	}
+	return nil
+}
+
+// zoneTokenText returns the character-string of t, unescaping unquoted fields.
+func zoneTokenText(t zoneToken) (string, error) {
+	if t.Quoted {
+		return t.Text, nil
+	}
+	return zoneUnescape(t.Text)
+}
+
+// parseZoneTTL parses a TTL in seconds or in BIND's unit notation (1h30m, 2d).
+func parseZoneTTL(s string) (int64, error) {
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil && v >= 0 {
+		return v, nil
+	}
+	units := map[byte]int64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var total, n int64
+	digits := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			n = n*10 + int64(c-'0')
+			digits = true
+		case units[c|0x20] != 0 && digits:
+			total += n * units[c|0x20]
+			n, digits = 0, false
+		default:
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+	}
+	if digits || total == 0 && s == "" {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+	return total, nil
+}
@@ -0,0 +1,149 @@
+package provider
+
+import "testing"
+
+func TestRenderZoneFile(t *testing.T) {
+	records := []zoneRecord{
+		{Name: "www", Type: "A", Content: "10.0.0.1", TTL: 300},
+		{Name: "", Type: "TXT", Content: `v=spf1 include:"x" -all`, TTL: 3600},
+		{Name: "", Type: "MX", Content: "mail.example.com", TTL: 3600, Priority: ptrI(10)},
+		{Name: "_sip._tcp", Type: "SRV", Content: "5 5060 pbx.example.com", TTL: 3600, Priority: ptrI(20)},
+		{Name: "", Type: "CAA", CAAValue: "letsencrypt.org", CAAFlags: ptrI(0), CAATag: "issue", TTL: 3600},
+		{Name: "blog", Type: "CNAME", Content: "example.github.io.", TTL: 3600},
+		{Name: "a.www", Type: "A", Content: "10.0.0.2", TTL: 3600},
+		{Name: "", Type: "TXT", Content: "café", TTL: 3600},
+	}
+	want := `$ORIGIN example.com.
+$TTL 3600
+@             IN CAA   0 issue "letsencrypt.org"
+@             IN MX    10 mail.example.com.
+@             IN TXT   "caf\195\169"
+@             IN TXT   "v=spf1 include:\"x\" -all"
+_sip._tcp     IN SRV   20 5 5060 pbx.example.com.
+blog          IN CNAME example.github.io.
+www       300 IN A     10.0.0.1
+a.www         IN A     10.0.0.2
+`
+	if got := renderZoneFile("example.com", records); got != want {
+		t.Errorf("unexpected zone file:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderZoneFileOrderIsStable(t *testing.T) {
+	records := []zoneRecord{
+		{Name: "www", Type: "A", Content: "10.0.0.2", TTL: 3600},
+		{Name: "www", Type: "AAAA", Content: "2001:db8::1", TTL: 3600},
+		{Name: "WWW", Type: "A", Content: "10.0.0.1", TTL: 3600},
+		{Name: "www", Type: "A", Content: "10.0.0.1", TTL: 3600},
+	}
+	want := `$ORIGIN example.com.
+$TTL 3600
+WWW  IN A    10.0.0.1
+www  IN A    10.0.0.1
+www  IN A    10.0.0.2
+www  IN AAAA 2001:db8::1
+`
+	reversed := make([]zoneRecord, len(records))
+	for i, z := range records {
+		reversed[len(records)-1-i] = z
+	}
+	for _, in := range [][]zoneRecord{records, reversed} {
+		if got := renderZoneFile("example.com", in); got != want {
+			t.Errorf("unexpected zone file:\n%s\nwant:\n%s", got, want)
+		}
+	}
+}
+
+func TestParseZoneFile(t *testing.T) {
+	text := `$ORIGIN example.com.
+$TTL 1h
+@	IN SOA ns1.active24.cz. hostmaster.active24.cz. (
+		2024010101 ; serial
+		7200 900 1209600 300 )
+	IN NS ns1.active24.cz.
+	IN MX 10 mail          ; relative to $ORIGIN
+@ 300 IN A 10.0.0.1
+www IN 600 A 10.0.0.2
+    AAAA 2001:db8::1
+_sip._tcp IN SRV 20 5 5060 pbx.example.com.
+@ TXT "v=spf1 include:\"spf.example.net\" -all"
+_dmarc TXT ( "v=DMARC1; "
+             "p=reject" )
+@ CAA 0 issue "letsencrypt.org"
+_25._tcp.mail TLSA 3 1 1 ( AABBCC
+                           DDEEFF )
+$ORIGIN dev.example.com.
+api CNAME @
+poštovní A 10.0.0.3
+`
+	got, err := parseZoneFile(text, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []zoneRecord{
+		{Name: "", Type: "MX", Content: "mail.example.com", TTL: 3600, Priority: ptrI(10)},
+		{Name: "", Type: "A", Content: "10.0.0.1", TTL: 300},
+		{Name: "www", Type: "A", Content: "10.0.0.2", TTL: 600},
+		{Name: "www", Type: "AAAA", Content: "2001:db8::1", TTL: 3600},
+		{Name: "_sip._tcp", Type: "SRV", Content: "5 5060 pbx.example.com", TTL: 3600, Priority: ptrI(20)},
+		{Name: "", Type: "TXT", Content: `v=spf1 include:"spf.example.net" -all`, TTL: 3600},
+		{Name: "_dmarc", Type: "TXT", Content: `"v=DMARC1; " "p=reject"`, TTL: 3600},
+		{Name: "", Type: "CAA", TTL: 3600, CAAFlags: ptrI(0), CAATag: "issue", CAAValue: "letsencrypt.org"},
+		{Name: "_25._tcp.mail", Type: "TLSA", Content: "3 1 1 AABBCCDDEEFF", TTL: 3600},
+		{Name: "api.dev", Type: "CNAME", Content: "dev.example.com", TTL: 3600},
+		{Name: "xn--potovn-8va73g.dev", Type: "A", Content: "10.0.0.3", TTL: 3600},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d records, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].key() != want[i].key() || got[i].TTL != want[i].TTL || !equalInt64Ptr(got[i].Priority, want[i].Priority) || got[i].Content != want[i].Content {
+			t.Errorf("record %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	tests := map[string]string{
+		"www A 10.0.0.1 )":                `line 1: unbalanced ")"`,
+		"www TXT ( \"a\"\n":               `line 1: unbalanced "("`,
+		"www TXT \"abc":                   "line 1: unterminated quoted string",
+		"\n\nwww.example.net. A 10.0.0.1": `line 3: name "www.example.net." is outside of zone example.com.`,
+		"www HINFO a b":                   `line 1: HINFO record "www": record type HINFO is not supported`,
+		"@ MX mail":                       `line 1: MX record "@": expected 2 fields, got 1`,
+		"$INCLUDE other.zone":             "line 1: $INCLUDE is not supported",
+		"$TTL 1x":                         `line 1: invalid TTL "1x"`,
+		"www CH A 10.0.0.1":               "line 1: class CH is not supported, only IN",
+		"  A 10.0.0.1":                    "line 1: record without owner name",
+		"_sip._tcp SRV 1 2 70000 pbx.":    `line 1: SRV record "_sip._tcp": "70000" is not a number between 0 and 65535`,
+	}
+	for text, want := range tests {
+		_, err := parseZoneFile(text, "example.com")
+		if err == nil || err.Error() != want {
+			t.Errorf("parseZoneFile(%q) error = %v, want %q", text, err, want)
+		}
+	}
+}
+
+func TestParseZoneFileRoundTrip(t *testing.T) {
+	records := []zoneRecord{
+		{Name: "", Type: "MX", Content: "mail.example.com", TTL: 3600, Priority: ptrI(10)},
+		{Name: "www", Type: "A", Content: "10.0.0.1", TTL: 300},
+		{Name: "_sip._tcp", Type: "SRV", Content: "5 5060 pbx.example.com", TTL: 3600, Priority: ptrI(20)},
+		{Name: "", Type: "TXT", Content: "café \"quoted\" back\\slash", TTL: 3600},
+		{Name: "", Type: "CAA", TTL: 3600, CAAFlags: ptrI(128), CAATag: "iodef", CAAValue: "mailto:ca@example.com"},
+	}
+	got, err := parseZoneFile(renderZoneFile("example.com", records), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := map[string]bool{}
+	for _, z := range got {
+		keys[z.key()] = true
+	}
+	for _, z := range records {
+		if !keys[z.key()] {
+			t.Errorf("record %+v lost in round trip, got %+v", z, got)
+		}
+	}
+}
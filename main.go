@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-    "github.com/JoystiC/terraform-provider-active24/internal/provider"
+
+	"github.com/JoystiC/terraform-provider-active24/internal/provider"
 )
 
 // version is set by goreleaser or at build time via -ldflags
@@ -13,6 +15,12 @@ var version = "dev"
 func main() {
 	ctx := context.Background()
 
+	// Terraform starts the provider without arguments; a subcommand means
+	// it was run by hand
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		os.Exit(provider.RunGenerate(ctx, os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	providerserver.Serve(ctx, provider.New(version), providerserver.ServeOpts{
 		Address: "registry.terraform.io/joystic/active24",
 	})